@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/marekforys/bridge-bid-tutor-go/internal/game"
+)
+
+// boardFile is a file format for boards, such as PBN or LIN.
+type boardFile struct {
+	read  func(io.Reader) ([]game.BoardRecord, error)
+	write func(io.Writer, []game.BoardRecord) error
+}
+
+var (
+	pbnFile = boardFile{read: game.ReadPBN, write: game.WritePBN}
+	linFile = boardFile{read: game.ReadLIN, write: game.WriteLIN}
+)
+
+// boardExport collects the boards played in this run and keeps them saved
+// in a file, so an interrupted session still leaves a usable file.
+type boardExport struct {
+	path    string
+	format  boardFile
+	records []game.BoardRecord
+}
+
+// boardExports saves every finished game to each of its files.
+type boardExports []*boardExport
+
+// add records a finished game and rewrites every file.
+func (exports boardExports) add(g *Game) error {
+	rec := game.NewBoardRecord(g.Board, g.Deal, g.Auction)
+	if g.Play != nil {
+		rec.Play = g.Play.Cards()
+		if g.Play.IsOver() {
+			rec.Result = g.Play.DeclarerTricks()
+		}
+	}
+	for _, e := range exports {
+		e.records = append(e.records, rec)
+
+		f, err := os.Create(e.path)
+		if err != nil {
+			return err
+		}
+		if err := e.format.write(f, e.records); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadBoards reads the boards of a PBN or LIN file.
+func loadBoards(path string, format boardFile) ([]game.BoardRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records, err := format.read(f)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s contains no boards", path)
+	}
+	return records, nil
+}
+
+// playRecords bids each board of a PBN or LIN file in turn, keeping its
+// number, dealer, vulnerability and hands. Any recorded auction is ignored.
+func playRecords(records []game.BoardRecord, exports boardExports) error {
+	for i, rec := range records {
+		if i > 0 {
+			prompt := promptui.Prompt{Label: fmt.Sprintf("Press Enter for board %d", rec.Board.Number)}
+			if _, err := prompt.Run(); err != nil {
+				return errAborted
+			}
+		}
+		g := NewGame(rec.Board, rec.Deal)
+		if err := g.Start(); err != nil {
+			return err
+		}
+		if err := exports.add(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadLIN reads the boards of a LIN file, or of a BBO handviewer link.
+func loadLIN(path string) ([]game.BoardRecord, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return game.ParseLIN(path)
+	}
+	return loadBoards(path, linFile)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/marekforys/bridge-bid-tutor-go/internal/game"
+)
+
+// runLeadTrainer runs the "lead" subcommand: opening lead problems, one
+// after another. You see the auction and the leader's hand, choose a lead,
+// and are shown how it compares with every other lead, double dummy over
+// layouts that fit the auction.
+func runLeadTrainer(args []string) error {
+	fs := flag.NewFlagSet("lead", flag.ExitOnError)
+	boardNumber := fs.Int("board", 1, "number of the first board; sets the dealer and vulnerability")
+	dealID := fs.String("deal", "", "29-digit deal ID to use for the first problem")
+	layouts := fs.Int("layouts", game.DefaultLeadLayouts, "number of simulated layouts each lead is judged on")
+	readSystems := addSystemFlags(fs)
+	_ = fs.Parse(args)
+	if *layouts < 1 {
+		return fmt.Errorf("-layouts must be at least 1")
+	}
+	systems, err := readSystems()
+	if err != nil {
+		return err
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	board := game.NewBoard(*boardNumber)
+	for i := 0; ; i++ {
+		if i > 0 {
+			prompt := promptui.Prompt{Label: "Press Enter for the next problem"}
+			if _, err := prompt.Run(); err != nil {
+				return errAborted
+			}
+		}
+		var lp game.LeadProblem
+		if i == 0 && *dealID != "" {
+			d, err := game.DealFromID(*dealID)
+			if err != nil {
+				return err
+			}
+			if lp, err = game.NewLeadProblem(board, d, systems); err != nil {
+				return err
+			}
+		} else {
+			lp = game.RandomLeadProblem(board, systems, r)
+		}
+		if err := leadProblem(lp, *layouts, r); err != nil {
+			return err
+		}
+		board = board.Next()
+	}
+}
+
+// leadProblem sets one problem and reports on the lead chosen.
+func leadProblem(lp game.LeadProblem, layouts int, r *rand.Rand) error {
+	leader := lp.Leader()
+	fmt.Print("\033[H\033[2J")
+	fmt.Println(lp.Board)
+	fmt.Printf("You are %s, on lead against %s.\n\n", leader, lp.Contract)
+	fmt.Println("Auction:")
+	for _, bid := range lp.Auction.Bids {
+		fmt.Printf("%s: %s\n", bid.Position, describeBid(bid))
+	}
+	fmt.Println()
+	hcp, _ := lp.Hand().Evaluate()
+	displayHand(fmt.Sprintf("Your hand (HCP: %d)", hcp), lp.Hand())
+
+	prompt := promptui.Prompt{
+		Label: "Your opening lead (e.g., 'AS', '10h')",
+		Validate: func(input string) error {
+			c, err := game.ParseCard(input)
+			if err != nil {
+				return err
+			}
+			if !lp.Hand().Contains(c) {
+				return fmt.Errorf("you do not hold the %s", c)
+			}
+			return nil
+		},
+	}
+	result, err := prompt.Run()
+	if err != nil {
+		if err == promptui.ErrInterrupt {
+			return errAborted
+		}
+		return fmt.Errorf("prompt failed: %w", err)
+	}
+	lead, _ := game.ParseCard(result) // Validation already passed
+
+	fmt.Printf("\nDealing %d layouts that fit the auction and solving them double dummy...\n", layouts)
+	ctx := context.Background() // The trainer waits for the analysis
+	sample, _ := lp.SampleLayouts(ctx, layouts, r)
+	if len(sample) == 0 {
+		fmt.Println("No layout fitting the auction was found; the lead cannot be judged.")
+		return nil
+	}
+	analysis, _ := game.AnalyzeLeads(ctx, lp.Contract, sample)
+	displayLeadAnalysis(lp, analysis, lead)
+
+	fmt.Println("\n--- The actual deal ---")
+	for pos := game.North; pos <= game.West; pos++ {
+		displayHand(pos.String(), lp.Deal.Hands[pos])
+	}
+	return nil
+}
+
+// displayLeadAnalysis compares the chosen lead with the others, best
+// first, and marks the lead the computer's defenders would make.
+func displayLeadAnalysis(lp game.LeadProblem, a game.LeadAnalysis, lead game.Card) {
+	score, rank, _ := a.Score(lead)
+	best := a.Scores[0]
+	fmt.Printf("\n--- Your lead: %s ---\n", lead)
+	fmt.Printf("Over %d layouts declarer takes %.1f tricks on average; %s fails %.0f%% of the time.\n",
+		a.Layouts, score.Tricks, lp.Contract, 100*score.Defeated)
+	if rank == 1 {
+		fmt.Println("That is the best lead.")
+	} else {
+		fmt.Printf("Ranked %d of %d. The best lead, %s, holds declarer to %.1f tricks (%.1f fewer).\n",
+			rank, len(a.Scores), best.Card, best.Tricks, score.Tricks-best.Tricks)
+	}
+
+	textbook := game.OpeningLead(lp.Hand(), lp.Contract, lp.Auction)
+	fmt.Println("\nLead  Declarer tricks  Contract fails")
+	for _, s := range a.Scores {
+		note := ""
+		switch {
+		case s.Card == lead:
+			note = "  <- your lead"
+		case s.Card == textbook:
+			note = "  <- textbook lead"
+		}
+		fmt.Printf("%-4s  %15.1f  %13.0f%%%s\n", s.Card, s.Tricks, 100*s.Defeated, note)
+	}
+}
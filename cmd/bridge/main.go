@@ -1,69 +1,269 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/manifoldco/promptui"
 	"github.com/marekforys/bridge-bid-tutor-go/internal/game"
 )
 
+// errAborted is returned when the user interrupts the game.
+var errAborted = errors.New("game aborted")
+
+// watchPlay is set when the computer plays South's cards too, so that the
+// user watches the play instead of taking part.
+var watchPlay bool
+
+// systems are the bidding systems the partnerships play, set from -system
+// and -opponents-system.
+var systems game.Systems
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "lead" {
+		if err := runLeadTrainer(os.Args[2:]); err != nil && !errors.Is(err, errAborted) {
+			log.Fatal(err)
+		}
+		fmt.Println("\nGoodbye!")
+		return
+	}
+
+	rubber := flag.Bool("rubber", false, "play a whole rubber, deal after deal, with rubber bridge scoring")
+	boardNumber := flag.Int("board", 1, "number of the first board; sets the dealer and vulnerability")
+	boards := flag.Int("boards", 1, "number of consecutive boards to play")
+	dealID := flag.String("deal", "", "29-digit deal ID to replay; the first board uses this deal")
+	constraints := flag.String("constraints", "", `only deal hands matching these constraints, e.g. "north:hcp=15-17,balanced;south:hearts=5+"`)
+	pbnIn := flag.String("pbn", "", "PBN file whose boards are bid one after another")
+	pbnOut := flag.String("pbn-out", "", "save every board played to this PBN file")
+	linIn := flag.String("lin", "", "BBO LIN file (or handviewer link) whose boards are bid one after another")
+	linOut := flag.String("lin-out", "", "save every board played to this LIN file")
+	flag.BoolVar(&watchPlay, "watch", false, "watch the computer play the cards after the auction instead of playing South")
+	readSystems := addSystemFlags(flag.CommandLine)
+	flag.Parse()
+
+	var err error
+	if systems, err = readSystems(); err != nil {
+		log.Fatal(err)
+	}
+
+	var exports boardExports
+	if *pbnOut != "" {
+		exports = append(exports, &boardExport{path: *pbnOut, format: pbnFile})
+	}
+	if *linOut != "" {
+		exports = append(exports, &boardExport{path: *linOut, format: linFile})
+	}
+
 	fmt.Println("Welcome to Bridge Bidding Tutor!")
+	fmt.Printf("North-South play %s, East-West play %s.\n", systems.For(game.NorthSouth), systems.For(game.EastWest))
 	fmt.Println("------------------------------")
 
-	// Initialize game
-	game := NewGame()
-
-	// Start the game loop
-	if err := game.Start(); err != nil {
+	switch {
+	case *rubber:
+		err = playRubber(exports)
+	case *pbnIn != "":
+		records, perr := loadBoards(*pbnIn, pbnFile)
+		if perr != nil {
+			log.Fatal(perr)
+		}
+		err = playRecords(records, exports)
+	case *linIn != "":
+		records, lerr := loadLIN(*linIn)
+		if lerr != nil {
+			log.Fatal(lerr)
+		}
+		err = playRecords(records, exports)
+	default:
+		var first *game.Deal
+		if *dealID != "" {
+			d, derr := game.DealFromID(*dealID)
+			if derr != nil {
+				log.Fatal(derr)
+			}
+			first = &d
+		}
+		var gen *game.DealGenerator
+		if *constraints != "" {
+			dc, cerr := game.ParseDealConstraints(*constraints)
+			if cerr != nil {
+				log.Fatal(cerr)
+			}
+			gen = game.NewDealGenerator(time.Now().UnixNano(), dc)
+		}
+		err = playBoards(game.NewBoard(*boardNumber), *boards, first, gen, exports)
+	}
+	if errors.Is(err, errAborted) {
+		fmt.Println("\nGame aborted. Goodbye!")
+		return
+	}
+	if err != nil {
 		log.Fatalf("Error starting game: %v", err)
 	}
 }
 
+// playBoards plays a series of consecutive boards, starting with first.
+// If firstDeal is set it is played on the first board. Other boards come
+// from gen when it is set, or are shuffled otherwise.
+func playBoards(first game.Board, count int, firstDeal *game.Deal, gen *game.DealGenerator, exports boardExports) error {
+	board := first
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			prompt := promptui.Prompt{Label: fmt.Sprintf("Press Enter for board %d", board.Number)}
+			if _, err := prompt.Run(); err != nil {
+				return errAborted
+			}
+		}
+		var deal game.Deal
+		switch {
+		case i == 0 && firstDeal != nil:
+			deal = *firstDeal
+		case gen != nil:
+			d, err := gen.Next(context.Background())
+			if err != nil {
+				return err
+			}
+			deal = d
+		default:
+			deal = game.NewShuffledDeal()
+		}
+		// Initialize game and start the game loop
+		g := NewGame(board, deal)
+		if err := g.Start(); err != nil {
+			return err
+		}
+		if err := exports.add(g); err != nil {
+			return err
+		}
+		board = board.Next()
+	}
+	return nil
+}
+
+// addSystemFlags defines -system and -opponents-system on a flag set,
+// with -nt-defense and -opponents-nt-defense for the defenses to 1NT the
+// partnerships play. The function returned reads the systems once the
+// flags are parsed.
+func addSystemFlags(fs *flag.FlagSet) func() (game.Systems, error) {
+	ours := fs.String("system", game.PolishClub.ID, "bidding system North-South play: polish-club, polish-club-multi, sayc, two-over-one, or a JSON file of rules")
+	theirs := fs.String("opponents-system", "", "bidding system East-West play, as for -system; the same as North-South if not given")
+	ourDefense := fs.String("nt-defense", game.NaturalNTDefense, "defense North-South play against 1NT: natural (penalty double), landy, cappelletti or dont")
+	theirDefense := fs.String("opponents-nt-defense", "", "defense East-West play against 1NT, as for -nt-defense; the same as North-South if not given")
+	return func() (game.Systems, error) {
+		if *theirs == "" {
+			*theirs = *ours
+		}
+		if *theirDefense == "" {
+			*theirDefense = *ourDefense
+		}
+		var s game.Systems
+		for side, name := range [2]string{*ours, *theirs} {
+			sys, err := loadSystem(name)
+			if err != nil {
+				return s, err
+			}
+			s[side] = sys
+		}
+		for side, name := range [2]string{*ourDefense, *theirDefense} {
+			defense, ok := game.FindNTDefense(name)
+			if !ok {
+				return s, fmt.Errorf("unknown 1NT defense %q", name)
+			}
+			if err := s.PlayNTDefense(game.Side(side), defense); err != nil {
+				return s, err
+			}
+		}
+		return s, nil
+	}
+}
+
+// loadSystem returns the built-in bidding system with a name, or else
+// reads one from the file of that name.
+func loadSystem(name string) (game.BiddingSystem, error) {
+	if sys, ok := game.FindSystem(name); ok {
+		return sys, nil
+	}
+	return game.LoadSystemFile(name)
+}
+
 // Game represents the main game state
 type Game struct {
-	Deck    game.Deck
+	Board   game.Board
+	Deal    game.Deal
 	Players []*game.Player
 	Auction *game.Auction
-	Dealer  game.Position
+	Dealer  game.Position      // Player whose turn it is to call
+	Rubber  *game.Rubber       // Set when playing rubber bridge; overrides the board's vulnerability
+	Play    *game.Play         // Card play, once the auction has produced a contract
+	Tricks  *game.PendingTable // Double-dummy tricks for par, solved while the auction runs
 }
 
-// NewGame creates a new game instance
-func NewGame() *Game {
-	// Initialize deck and shuffle
-	deck := game.NewDeck()
-	deck.Shuffle()
+// NewGame creates a new game instance for the given board and deal
+func NewGame(board game.Board, deal game.Deal) *Game {
+	players := game.NewPlayers(deal)
+	systems.Seat(players)
+	auction := game.NewAuction()
+	auction.Vulnerability = board.Vulnerability
+	return &Game{
+		Board:   board,
+		Deal:    deal,
+		Players: players,
+		Auction: auction,
+		Dealer:  board.Dealer,
+	}
+}
 
-	// Create players
-	players := make([]*game.Player, 4)
-	for i := 0; i < 4; i++ {
-		players[i] = game.NewPlayer(game.Position(i))
+// Start plays a single deal: the auction, a review of all four hands, the
+// card play and the score.
+func (g *Game) Start() error {
+	g.Tricks = game.SolveDealAsync(context.Background(), g.Deal) // The CLI waits for par
+	contract, err := g.RunAuction()
+	if err != nil {
+		return err
 	}
+	g.displayResult(contract)
 
-	// Deal cards
-	for i := 0; i < 52; i++ {
-		players[i%4].Hand.Cards = append(players[i%4].Hand.Cards, deck[i])
+	if contract.PassedOut {
+		fmt.Println("\nThe deal was passed out. No score.")
+		fmt.Println("------------------------------")
+		return nil
 	}
 
-	// Sort each player's hand
-	for _, p := range players {
-		p.Hand.Sort()
+	if err := g.PlayHand(contract); err != nil {
+		return err
 	}
 
-	return &Game{
-		Deck:    deck,
-		Players: players,
-		Auction: game.NewAuction(),
-		Dealer:  game.North, // First dealer is North
+	// Calculate and display the score
+	tricks := g.Play.DeclarerTricks()
+	score := game.ScoreContract(contract, g.Board.Vulnerable(contract.Declarer), tricks)
+	fmt.Println("\n--- Score ---")
+	fmt.Printf("Contract: %s, %d tricks\n", contract, tricks)
+	fmt.Printf("Result: %d points\n", score.TotalScore)
+	if score.Undertricks > 0 {
+		fmt.Printf("(Down %d, Penalty: %d)\n", score.Undertricks, score.PenaltyScore)
+	} else {
+		fmt.Printf("(Trick Score: %d, Overtricks: %d, Bonus: %d)\n", score.TrickScore, score.OvertrickScore, score.BonusScore)
 	}
+	if score.MadeGame {
+		fmt.Println("Game bonus awarded!")
+	}
+	if score.MadeSlam {
+		fmt.Println("Slam bonus awarded!")
+	}
+	fmt.Println("------------------------------")
+
+	return nil
 }
 
-// Start begins the game loop
-func (g *Game) Start() error {
+// RunAuction runs the bidding until the auction is over and returns the final contract.
+func (g *Game) RunAuction() (game.Contract, error) {
 	// Game loop
-	for !g.Auction.IsAuctionComplete() {
+	for !g.Auction.IsOver() {
 		// Get current player
 		currentPlayer := g.Players[g.Dealer]
 
@@ -75,35 +275,41 @@ func (g *Game) Start() error {
 		if currentPlayer.IsHuman() {
 			// Human player's turn
 			prompt := promptui.Prompt{
-				Label: "Enter your bid (e.g., '1H', 'pass', 'double')",
+				Label: "Enter your bid (e.g., '1H', 'pass', 'double'), or '?' to explain the auction",
 				Validate: func(input string) error {
+					if isExplainRequest(input) {
+						return nil
+					}
 					parsedBid, err := parseBid(input)
 					if err != nil {
 						return err
 					}
-					if !g.Auction.IsValidBid(parsedBid) {
-						return fmt.Errorf("must be higher than previous bid")
-					}
-					return nil
+					return g.Auction.ValidateBid(parsedBid)
 				},
 			}
 
-			result, err := prompt.Run()
-			if err != nil {
-				// Handle user interruption (e.g., Ctrl+C)
-				if err == promptui.ErrInterrupt {
-					fmt.Println("\nGame aborted. Goodbye!")
-					return nil
+			for {
+				result, err := prompt.Run()
+				if err != nil {
+					// Handle user interruption (e.g., Ctrl+C)
+					if err == promptui.ErrInterrupt {
+						return game.Contract{}, errAborted
+					}
+					return game.Contract{}, fmt.Errorf("prompt failed: %w", err)
 				}
-				return fmt.Errorf("prompt failed: %w", err)
+				if isExplainRequest(result) {
+					g.displayExplanations()
+					continue
+				}
+				bid, _ = parseBid(result) // We can ignore the error here because validation already passed
+				bid.Explanation = currentPlayer.ExplainBid(g.Auction, bid)
+				break
 			}
 
-			bid, _ = parseBid(result) // We can ignore the error here because validation already passed
-
 		} else {
 			// AI's turn
 			bid = currentPlayer.MakeBid(g.Auction)
-			fmt.Printf("%s bids: %s\n", currentPlayer.Position, bid)
+			fmt.Printf("%s bids: %s\n", currentPlayer.Position, describeBid(bid))
 		}
 
 		// Add bid to auction
@@ -114,28 +320,65 @@ func (g *Game) Start() error {
 	}
 
 	// Auction is complete
+	return game.NewContract(g.Auction)
+}
+
+// displayResult shows the final contract and all four hands.
+func (g *Game) displayResult(contract game.Contract) {
 	fmt.Println("\nAuction complete!")
-	lastBid, _ := g.Auction.LastNonPassBid()
-	fmt.Println("Final contract:", lastBid)
+	fmt.Println("Final contract:", contract)
+	fmt.Println("Deal ID:", g.Deal.ID())
+	if !contract.PassedOut {
+		fmt.Printf("Dummy: %s, opening lead: %s\n", contract.Dummy(), contract.OpeningLeader())
+	}
 	fmt.Println("------------------------------")
 
 	g.displayAllHands()
+	if g.Rubber == nil { // Par is a duplicate measure
+		g.displayPar(contract)
+	}
+}
 
-	// Calculate and display the score
-	score := game.CalculateScore(lastBid, game.NotVulnerable)
-	fmt.Println("\n--- Score ---")
-	fmt.Printf("Contract: %s\n", lastBid)
-	fmt.Printf("Result: %d points\n", score.TotalScore)
-	fmt.Printf("(Trick Score: %d, Bonus: %d)\n", score.TrickScore, score.BonusScore)
-	if score.MadeGame {
-		fmt.Println("Game bonus awarded!")
+// displayPar compares the final contract, played double dummy, with the
+// par result for the deal.
+func (g *Game) displayPar(contract game.Contract) {
+	table, ok := g.Tricks.Ready()
+	if !ok {
+		fmt.Println("\nSolving the deal double dummy...")
+		var err error
+		if table, err = g.Tricks.Wait(); err != nil {
+			fmt.Printf("\nPar is unavailable: %v\n", err)
+			return
+		}
 	}
-	if score.MadeSlam {
-		fmt.Println("Slam bonus awarded!")
+	vul := g.Board.Vulnerability
+	par := game.CalculatePar(table, g.Board.Dealer, vul)
+	score := game.DoubleDummyScore(contract, table, vul)
+
+	fmt.Println("\n--- Par ---")
+	if par.Contract.PassedOut {
+		fmt.Println("Par: Passed out")
+	} else {
+		fmt.Printf("Par: %s, %d tricks, N-S %+d\n", par.Contract, par.Tricks, par.Score)
+	}
+	if contract.PassedOut {
+		fmt.Println("Your result: Passed out, N-S 0")
+	} else {
+		fmt.Printf("Your contract: %s, %d tricks double dummy, N-S %+d\n", contract,
+			table.Tricks(contract.Strain, contract.Declarer), score)
 	}
+	diff := score - par.Score
+	fmt.Printf("Difference for N-S: %+d points (%+d IMPs)\n", diff, game.IMPs(diff))
 	fmt.Println("------------------------------")
+}
 
-	return nil
+// Hands returns the four hands indexed by position.
+func (g *Game) Hands() [4]*game.Hand {
+	var hands [4]*game.Hand
+	for _, p := range g.Players {
+		hands[p.Position] = p.Hand
+	}
+	return hands
 }
 
 // displayGameState shows the current game state to the player
@@ -143,10 +386,18 @@ func (g *Game) displayGameState(currentPlayer *game.Player) {
 	// Clear screen
 	fmt.Print("\033[H\033[2J")
 
+	if g.Rubber != nil {
+		fmt.Printf("Rubber deal (Dealer %s, N-S vul: %v, E-W vul: %v)\n", g.Board.Dealer,
+			bool(g.Rubber.Vulnerable(game.NorthSouth)), bool(g.Rubber.Vulnerable(game.EastWest)))
+	} else {
+		fmt.Println(g.Board)
+	}
+	fmt.Println()
+
 	// Show auction history
 	fmt.Println("Auction:")
 	for _, bid := range g.Auction.Bids {
-		fmt.Printf("%s: %s\n", bid.Position, bid)
+		fmt.Printf("%s: %s\n", bid.Position, describeBid(bid))
 	}
 	fmt.Println()
 
@@ -158,8 +409,70 @@ func (g *Game) displayGameState(currentPlayer *game.Player) {
 		fmt.Println("Hearts:", currentPlayer.Hand.GetSuit(game.Hearts))
 		fmt.Println("Diamonds:", currentPlayer.Hand.GetSuit(game.Diamonds))
 		fmt.Println("Clubs:", currentPlayer.Hand.GetSuit(game.Clubs))
+		h := currentPlayer.Hand
+		fmt.Printf("Losers: %d, Zar: %d, CCCC: %.2f, quick tricks: %g, controls: %d\n",
+			h.LosingTrickCount(), h.ZarPoints(), h.KaplanRubens(), h.QuickTricks(), h.Controls())
 		fmt.Println()
+		if shown := currentPlayer.PartnerPicture(g.Auction); shown.Shown() {
+			fmt.Printf("Partner has shown: %s\n\n", shown)
+		}
+	}
+}
+
+// displayExplanations shows what each call of the auction so far means,
+// in full, and what the calls add up to for each seat, for the player who
+// asks.
+func (g *Game) displayExplanations() {
+	fmt.Println("\nWhat the calls mean:")
+	if len(g.Auction.Bids) == 0 {
+		fmt.Println("  Nobody has called yet.")
+	}
+	for _, bid := range g.Auction.Bids {
+		e := "no agreement"
+		if bid.Explanation != nil && bid.Explanation.String() != "" {
+			e = bid.Explanation.String()
+		}
+		fmt.Printf("  %s %s: %s\n", bid.Position, bid, e)
+	}
+	fmt.Println("\nWhat each seat has shown:")
+	for pos := game.North; pos <= game.West; pos++ {
+		shown := "nothing yet"
+		if p := g.Auction.Picture(pos); p.Shown() {
+			shown = p.String()
+		}
+		fmt.Printf("  %s: %s\n", pos, shown)
+	}
+	fmt.Println()
+}
+
+// describeBid writes a call with a short note of what it means: an alert,
+// whether it is forcing, and its meaning in words.
+func describeBid(bid game.Bid) string {
+	e := bid.Explanation
+	if e == nil || e.Meaning == "" && !e.Alert && !e.Forcing {
+		return bid.String()
+	}
+	var notes []string
+	if e.Alert {
+		notes = append(notes, "Alert")
+	}
+	if e.Forcing {
+		notes = append(notes, "forcing")
+	}
+	if e.Meaning != "" {
+		notes = append(notes, e.Meaning)
+	}
+	return fmt.Sprintf("%-8s %s", bid, strings.Join(notes, "; "))
+}
+
+// isExplainRequest reports whether the player asked what the calls mean
+// instead of calling.
+func isExplainRequest(input string) bool {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "?", "explain":
+		return true
 	}
+	return false
 }
 
 // displayAllHands shows all four hands at the end of the auction.
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/marekforys/bridge-bid-tutor-go/internal/game"
+)
+
+// PlayHand plays the contract out trick by trick. You choose the cards for
+// South, and for dummy when South declares; the computer plays the rest,
+// planning the play when it declares and signalling when it defends. With
+// -watch the computer plays every card.
+func (g *Game) PlayHand(contract game.Contract) error {
+	play, err := game.NewPlay(contract, g.Deal)
+	if err != nil {
+		return err
+	}
+	g.Play = play
+	declarer := game.NewDeclarerAI(time.Now().UnixNano())
+	defenders := game.NewDefenderAI(g.Auction)
+
+	for !play.IsOver() {
+		seat := play.Turn
+		var card game.Card
+		if g.Players[play.Controller(seat)].IsHuman() && !watchPlay {
+			g.displayPlayState()
+			prompt := promptui.Prompt{
+				Label: fmt.Sprintf("Card to play from %s (e.g., 'AS', '10h')", seat),
+				Validate: func(input string) error {
+					c, err := game.ParseCard(input)
+					if err != nil {
+						return err
+					}
+					return play.ValidatePlay(seat, c)
+				},
+			}
+			result, err := prompt.Run()
+			if err != nil {
+				if err == promptui.ErrInterrupt {
+					return errAborted
+				}
+				return fmt.Errorf("prompt failed: %w", err)
+			}
+			card, _ = game.ParseCard(result) // Validation already passed
+		} else {
+			if seat.Side() == contract.Declarer.Side() {
+				card = declarer.ChooseCard(play)
+			} else {
+				card = defenders.ChooseCard(play)
+			}
+			fmt.Printf("%s plays %s\n", seat, card)
+		}
+
+		if err := play.PlayCard(seat, card); err != nil {
+			return err
+		}
+		if t := play.CurrentTrick(); t == nil {
+			last := play.LastTrick()
+			fmt.Printf("Trick %d won by %s (%s)\n", len(play.Tricks), last.Winner, formatTrick(last))
+		}
+	}
+
+	fmt.Printf("\nPlay complete: declarer took %d tricks.\n", play.DeclarerTricks())
+	return nil
+}
+
+// displayPlayState shows the contract, tricks won, dummy once it is down,
+// the trick in progress and your hand.
+func (g *Game) displayPlayState() {
+	play := g.Play
+	fmt.Print("\033[H\033[2J")
+	fmt.Printf("Contract: %s    Tricks N-S: %d  E-W: %d\n\n", play.Contract,
+		play.TricksWon(game.NorthSouth), play.TricksWon(game.EastWest))
+
+	dummy := play.Contract.Dummy()
+	if play.DummyVisible() && dummy != game.South {
+		displayHand(fmt.Sprintf("Dummy (%s)", dummy), play.Hands[dummy])
+	}
+	if last := play.LastTrick(); last != nil {
+		fmt.Printf("Last trick: %s, won by %s\n", formatTrick(last), last.Winner)
+	}
+	if t := play.CurrentTrick(); t != nil {
+		fmt.Printf("Current trick: %s\n", formatTrick(t))
+	}
+	fmt.Println()
+	displayHand("Your hand", play.Hands[game.South])
+}
+
+// displayHand prints a hand suit by suit.
+func displayHand(title string, h *game.Hand) {
+	fmt.Printf("%s:\n", title)
+	fmt.Println("  Spades:  ", h.GetSuit(game.Spades))
+	fmt.Println("  Hearts:  ", h.GetSuit(game.Hearts))
+	fmt.Println("  Diamonds:", h.GetSuit(game.Diamonds))
+	fmt.Println("  Clubs:   ", h.GetSuit(game.Clubs))
+	fmt.Println()
+}
+
+// formatTrick lists the cards of a trick with the seat that played each.
+func formatTrick(t *game.Trick) string {
+	cards := make([]string, len(t.Cards))
+	for i, c := range t.Cards {
+		cards[i] = fmt.Sprintf("%s %s", t.PlayedBy(i), c)
+	}
+	return strings.Join(cards, ", ")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+	"github.com/marekforys/bridge-bid-tutor-go/internal/game"
+)
+
+// playRubber deals, bids and plays hands until one side has won two games,
+// keeping a rubber bridge score sheet between deals. Each deal is saved
+// to every export file.
+func playRubber(exports boardExports) error {
+	rubber := game.NewRubber()
+	board := game.NewBoard(1)
+
+	for !rubber.IsComplete() {
+		// Boards only set the dealer here: vulnerability comes from the
+		// rubber, so the board's own vulnerability is ignored.
+		g := NewGame(board, game.NewShuffledDeal())
+		g.Rubber = rubber
+		g.Auction.Vulnerability = rubber.Vulnerability()
+
+		contract, err := g.RunAuction()
+		if err != nil {
+			return err
+		}
+		g.displayResult(contract)
+
+		tricks := 0
+		if !contract.PassedOut {
+			if err := g.PlayHand(contract); err != nil {
+				return err
+			}
+			tricks = g.Play.DeclarerTricks()
+		}
+		entry := rubber.Record(contract, tricks, game.Honours(contract, g.Hands()))
+		displayRubber(rubber, entry)
+		if err := exports.add(g); err != nil {
+			return err
+		}
+
+		// The deal passes to the left after every hand, passed out or not.
+		board = board.Next()
+
+		if !rubber.IsComplete() {
+			prompt := promptui.Prompt{Label: "Press Enter for the next deal"}
+			if _, err := prompt.Run(); err != nil {
+				return errAborted
+			}
+		}
+	}
+
+	winner, _ := rubber.Winner()
+	totals := rubber.Totals()
+	fmt.Printf("\nRubber complete! %s win by %d points.\n", winner, totals[winner]-totals[winner.Opponents()])
+	return nil
+}
+
+// displayRubber prints the score sheet after a deal.
+func displayRubber(rubber *game.Rubber, entry game.RubberEntry) {
+	fmt.Println("\n--- Rubber Score ---")
+	if !entry.Contract.PassedOut {
+		fmt.Printf("%s made %d tricks\n", entry.Contract, entry.Tricks)
+	}
+	fmt.Printf("%-12s %8s %8s\n", "", "N-S", "E-W")
+	fmt.Printf("%-12s %8d %8d\n", "Above line", rubber.AboveLine[game.NorthSouth], rubber.AboveLine[game.EastWest])
+	fmt.Printf("%-12s %8d %8d\n", "Below line", rubber.BelowLine[game.NorthSouth], rubber.BelowLine[game.EastWest])
+	fmt.Printf("%-12s %8d %8d\n", "Part-score", rubber.PartScore[game.NorthSouth], rubber.PartScore[game.EastWest])
+	fmt.Printf("%-12s %8d %8d\n", "Games", rubber.Games[game.NorthSouth], rubber.Games[game.EastWest])
+	fmt.Printf("%-12s %8v %8v\n", "Vulnerable", bool(rubber.Vulnerable(game.NorthSouth)), bool(rubber.Vulnerable(game.EastWest)))
+	fmt.Println("------------------------------")
+}
@@ -0,0 +1,706 @@
+package game
+
+import "testing"
+
+// TestAI_Competitive checks overcalls, takeout doubles and the calls that
+// follow them. Every built-in system shares them, so each case is bid
+// playing each system.
+func TestAI_Competitive(t *testing.T) {
+	systems := []*System{PolishClub, SAYC}
+
+	// Overcalls and doubles of the opponents' opening
+	t.Run("1NT overcall", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): Q 4 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "1NT", systems...)
+	})
+
+	t.Run("Simple overcall", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (5): K Q J 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "1S", systems...)
+	})
+
+	t.Run("Two-level overcall", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): Q 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Two},
+			// Hearts (3): K 5 4 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (5): A K J 7 6 -> 8 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: North})
+
+		expectCall(t, bidder, auction, "2D", systems...)
+	})
+
+	t.Run("Too weak to overcall at the 2 level", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): Q 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Two},
+			// Hearts (3): K 5 4 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (5): Q J 8 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: North})
+
+		expectCall(t, bidder, auction, "Pass", systems...)
+	})
+
+	t.Run("Weak jump overcall", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "2S", systems...)
+	})
+
+	t.Run("Suit too poor for a weak jump overcall", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): Q 9 7 5 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Seven}, {Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "Pass", systems...)
+	})
+
+	t.Run("Takeout double", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): A Q 3 2 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (4): K J 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (4): Q 4 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "X", systems...)
+	})
+
+	t.Run("Too few quick tricks to double", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): Q J 5 4 -> 3 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (1): 2
+			{Suit: Hearts, Rank: Two},
+			// Diamonds (4): Q J 5 4 -> 3 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Five}, {Suit: Diamonds, Rank: Four},
+			// Clubs (4): K Q J 3 -> 6 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "Pass", systems...)
+	})
+
+	t.Run("Strong double", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (5): A K Q 3 2 -> 9 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): A 4 -> 4 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K Q 6 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): K 3 2 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "X", systems...)
+	})
+
+	t.Run("Overcall of a weak two", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (5): A Q J 3 2 -> 7 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): Q 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "2S", systems...)
+	})
+
+	t.Run("Takeout double of a weak two", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): A Q 3 2 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (4): K J 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (4): K 4 3 2 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: North})
+
+		expectCall(t, bidder, auction, "X", systems...)
+	})
+
+	t.Run("Overcall in the pass-out seat", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (5): K Q J 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "1S", systems...)
+	})
+
+	// Advancing an overcall
+	t.Run("Raise of an overcall", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): Q 4 3 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): J 4 3 -> 1 HCP
+			{Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2S", systems...)
+	})
+
+	t.Run("Cue-bid raise", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): Q 4 3 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): A K 6 5 -> 7 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): K 4 3 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2H", systems...)
+	})
+
+	t.Run("Preemptive raise", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): Q 4 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (4): 5 4 3 2
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (1): 3
+			{Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "3S", systems...)
+	})
+
+	t.Run("New suit", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (1): 4
+			{Suit: Spades, Rank: Four},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (5): K Q 7 6 5 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (4): A J 4 3 -> 5 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2D", systems...)
+	})
+
+	t.Run("1NT advance", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (1): 4
+			{Suit: Spades, Rank: Four},
+			// Hearts (4): K Q 4 3 -> 5 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): Q 7 6 5 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (4): K 4 3 2 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "1NT", systems...)
+	})
+
+	t.Run("Game after a 1NT overcall", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): Q 4 3 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): A K 6 5 -> 7 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): K 4 3 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "3NT", systems...)
+	})
+
+	// Answering a takeout double
+	t.Run("Major after a double", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): Q 4 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "1S", systems...)
+	})
+
+	t.Run("Jump in a major after a double", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K J 3 2 -> 4 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (2): Q 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2S", systems...)
+	})
+
+	t.Run("Cue-bid after a double", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): A Q 3 2 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): K 4 3 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2H", systems...)
+	})
+
+	t.Run("1NT after a double", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): J 3 2 -> 1 HCP
+			{Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (3): K 3 2 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): Q 4 3 2 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Four}, {Suit: Diamonds, Rank: Three}, {Suit: Diamonds, Rank: Two},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "1NT", systems...)
+	})
+
+	t.Run("Longest unbid suit after a double", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): 4 3 2
+			{Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (3): 4 3 2
+			{Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): Q 4 3 2 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Four}, {Suit: Diamonds, Rank: Three}, {Suit: Diamonds, Rank: Two},
+			// Clubs (3): Q 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2D", systems...)
+	})
+
+	// The overcaller's and the doubler's rebids
+	t.Run("Minimum overcall after a cue-bid raise", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (5): K Q J 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "2S", systems...)
+	})
+
+	t.Run("Game after a cue-bid raise", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (5): A K J 3 2 -> 8 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K Q 6 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): Q 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "4S", systems...)
+	})
+
+	t.Run("Doubler's major after a cue-bid", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): A Q 3 2 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (4): K J 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (4): Q 4 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "2S", systems...)
+	})
+
+	t.Run("Doubler's game raise", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): A K Q 2 -> 9 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (4): A K J 6 -> 8 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Six},
+			// Clubs (4): Q 4 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "4S", systems...)
+	})
+
+	t.Run("Advancer's cue-bid raise once they bid again", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): Q 4 3 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): A K 6 5 -> 7 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): K 4 3 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: South})
+
+		expectCall(t, bidder, auction, "3H", systems...)
+	})
+
+	t.Run("Negative double", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (4): K J 7 6 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six},
+			// Diamonds (3): 4 3 2
+			{Suit: Diamonds, Rank: Four}, {Suit: Diamonds, Rank: Three}, {Suit: Diamonds, Rank: Two},
+			// Clubs (4): Q J 7 6 -> 3 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Seven}, {Suit: Clubs, Rank: Six},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+
+		expectCall(t, bidder, auction, "X", systems...)
+	})
+	t.Run("Competitive raise over an overcall", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): 6 5 4
+			{Suit: Spades, Rank: Six}, {Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (3): K 8 7 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Seven},
+			// Diamonds (4): Q J 5 2 -> 3 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Five}, {Suit: Diamonds, Rank: Two},
+			// Clubs (3): 9 8 3
+			{Suit: Clubs, Rank: Nine}, {Suit: Clubs, Rank: Eight}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+
+		expectCall(t, bidder, auction, "2H", systems...)
+	})
+	t.Run("Cue-bid limit raise over an overcall", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K 8 7 6 -> 3 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Seven}, {Suit: Spades, Rank: Six},
+			// Hearts (3): A 7 5 -> 4 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Five},
+			// Diamonds (4): K J 4 2 -> 4 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Four}, {Suit: Diamonds, Rank: Two},
+			// Clubs (2): 5 3
+			{Suit: Clubs, Rank: Five}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+
+		expectCall(t, bidder, auction, "3C", systems...)
+	})
+	t.Run("Jordan 2NT over a takeout double", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): Q 5 4 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (4): K J 8 6 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Six},
+			// Diamonds (3): A 7 3 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Three},
+			// Clubs (3): 6 5 2
+			{Suit: Clubs, Rank: Six}, {Suit: Clubs, Rank: Five}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+
+		expectCall(t, bidder, auction, "2NT", systems...)
+	})
+	t.Run("Support double", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 8 4
+			{Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Four},
+			// Hearts (3): K 7 6 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six},
+			// Diamonds (3): A J 5 -> 5 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Five},
+			// Clubs (5): K Q 5 4 2 -> 5 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Five}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Clubs, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: South})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: West})
+
+		expectCall(t, bidder, auction, "X", systems...)
+	})
+	t.Run("Advancer's free bid once they raise", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): Q J 7 5 -> 3 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Seven}, {Suit: Spades, Rank: Five},
+			// Hearts (3): 8 4 3
+			{Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (3): K 6 4 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Four},
+			// Clubs (3): J 5 3 -> 1 HCP
+			{Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Five}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: South})
+
+		expectCall(t, bidder, auction, "2S", systems...)
+	})
+}
@@ -0,0 +1,965 @@
+package game
+
+import "testing"
+
+// TestAI_NTDefense checks the defenses to the opponents' 1NT: the natural
+// one every built-in system plays, then Landy, Cappelletti and DONT, with
+// advancer's relays and preferences.
+func TestAI_NTDefense(t *testing.T) {
+	natural := PolishClub
+	landy := PolishClub.With(Landy)
+	cappelletti := SAYC.With(Cappelletti)
+	dont := SAYC.With(DONT)
+
+	// The natural defense: a penalty double and natural overcalls
+	t.Run("Penalty double", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): A 4 3 -> 4 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "X", natural)
+	})
+
+	t.Run("Natural overcall in a 6-card suit", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): A 4 -> 4 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2S", natural)
+	})
+
+	t.Run("Natural overcall in a 5-card major", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (5): A Q J 3 2 -> 7 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): Q 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2H", natural)
+	})
+
+	t.Run("Pass with nothing to show", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): Q 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (3): K 5 4 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): J 8 7 6 -> 1 HCP
+			{Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): Q 4 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "Pass", natural)
+	})
+
+	t.Run("Penalty double in the pass-out seat", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): A 4 3 -> 4 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "X", natural)
+	})
+
+	t.Run("Rescue from the double", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): 5 4 3 2
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (5): J 8 7 6 5 -> 1 HCP
+			{Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six}, {Suit: Hearts, Rank: Five},
+			// Diamonds (2): 3 2
+			{Suit: Diamonds, Rank: Three}, {Suit: Diamonds, Rank: Two},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2H", natural)
+	})
+
+	t.Run("Pass of the penalty double", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): Q 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (3): K 5 4 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): J 8 7 6 -> 1 HCP
+			{Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): Q 4 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "Pass", natural)
+	})
+
+	t.Run("Game raise of an overcall", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): A 3 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Three},
+			// Hearts (4): K Q 5 4 -> 5 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): A 8 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "4S", natural)
+	})
+
+	t.Run("Invitational raise of an overcall", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A 4 3 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (4): K Q 5 4 -> 5 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 2 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Two},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "3S", natural)
+	})
+
+	t.Run("Accepts the invitation", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): A 4 -> 4 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 3, Strain: Spades, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "4S", natural)
+	})
+
+	t.Run("Pass without a fit once responder bids", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): A 3 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Three},
+			// Hearts (4): K Q 5 4 -> 5 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): A 8 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Level: 3, Strain: NoTrump, Position: South})
+
+		expectCall(t, bidder, auction, "Pass", natural)
+	})
+
+	t.Run("Raise once responder bids", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K J 4 3 -> 4 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (2): K Q -> 5 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen},
+			// Diamonds (4): A 8 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Level: 3, Strain: NoTrump, Position: South})
+
+		expectCall(t, bidder, auction, "4S", natural)
+	})
+
+	// Landy: 2♣ for both majors
+	t.Run("Landy", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K Q 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (5): A J 8 7 6 -> 5 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six},
+			// Diamonds (2): 5 4
+			{Suit: Diamonds, Rank: Five}, {Suit: Diamonds, Rank: Four},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2C", landy)
+	})
+
+	t.Run("Landy: natural clubs", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (2): 4 3
+			{Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (6): A Q J 9 3 2 -> 7 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Nine}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "3C", landy)
+	})
+
+	t.Run("Landy: penalty double", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): A 4 3 -> 4 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "X", landy)
+	})
+
+	t.Run("Landy: longer hearts", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (4): Q 4 3 2 -> 2 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2H", landy)
+	})
+
+	t.Run("Landy: relay with equal majors", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): 5 4 3
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): Q 3 2 -> 2 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2D", landy)
+	})
+
+	t.Run("Landy: game", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): A 4 3 2 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (3): K Q 5 -> 5 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Five},
+			// Diamonds (4): A 7 6 5 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "4S", landy)
+	})
+
+	t.Run("Landy: asks with a strong hand", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A 4 3 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K Q 5 -> 5 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Five},
+			// Diamonds (4): A 7 6 5 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2NT", landy)
+	})
+
+	t.Run("Landy: pass with long clubs", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (1): 5
+			{Suit: Spades, Rank: Five},
+			// Hearts (2): 4 3
+			{Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): 8 7 6 5
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (6): Q J 8 7 6 5 -> 3 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Eight}, {Suit: Clubs, Rank: Seven}, {Suit: Clubs, Rank: Six}, {Suit: Clubs, Rank: Five},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "Pass", landy)
+	})
+
+	t.Run("Landy: the longer major", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K Q 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (5): A J 8 7 6 -> 5 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six},
+			// Diamonds (2): 5 4
+			{Suit: Diamonds, Rank: Five}, {Suit: Diamonds, Rank: Four},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "2H", landy)
+	})
+
+	t.Run("Landy: maximum with spades", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (5): A K J 3 2 -> 8 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (4): Q J 7 6 -> 3 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six},
+			// Diamonds (2): 5 4
+			{Suit: Diamonds, Rank: Five}, {Suit: Diamonds, Rank: Four},
+			// Clubs (2): K 2 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "4S", landy)
+	})
+
+	t.Run("Landy: minimum with hearts", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K Q 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (5): A J 8 7 6 -> 5 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six},
+			// Diamonds (2): 5 4
+			{Suit: Diamonds, Rank: Five}, {Suit: Diamonds, Rank: Four},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "3H", landy)
+	})
+
+	// Cappelletti
+	t.Run("Cappelletti: one-suiter", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): A 4 -> 4 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2C", cappelletti)
+	})
+
+	t.Run("Cappelletti: both majors", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K Q 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (5): A J 8 7 6 -> 5 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six},
+			// Diamonds (2): 5 4
+			{Suit: Diamonds, Rank: Five}, {Suit: Diamonds, Rank: Four},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2D", cappelletti)
+	})
+
+	t.Run("Cappelletti: hearts and a minor", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (5): A Q J 3 2 -> 7 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2H", cappelletti)
+	})
+
+	t.Run("Cappelletti: both minors", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (1): 5
+			{Suit: Spades, Rank: Five},
+			// Hearts (1): 4
+			{Suit: Hearts, Rank: Four},
+			// Diamonds (5): K Q 8 7 6 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (6): A J 8 7 6 5 -> 5 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Eight}, {Suit: Clubs, Rank: Seven}, {Suit: Clubs, Rank: Six}, {Suit: Clubs, Rank: Five},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2NT", cappelletti)
+	})
+
+	t.Run("Cappelletti: penalty double", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): A 4 3 -> 4 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "X", cappelletti)
+	})
+
+	t.Run("Cappelletti: relay", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): 5 4 3
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): Q 3 2 -> 2 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2D", cappelletti)
+	})
+
+	t.Run("Cappelletti: pass with diamonds", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (2): 4 3
+			{Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (6): A Q J 9 3 2 -> 7 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Nine}, {Suit: Diamonds, Rank: Three}, {Suit: Diamonds, Rank: Two},
+			// Clubs (3): K 7 6 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Seven}, {Suit: Clubs, Rank: Six},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "Pass", cappelletti)
+	})
+
+	t.Run("Cappelletti: shows the suit", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): A 4 -> 4 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "2S", cappelletti)
+	})
+
+	t.Run("Cappelletti: asks for the minor", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (5): K Q 4 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2NT", cappelletti)
+	})
+
+	t.Run("Cappelletti: pass with support", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K 4 3 2 -> 3 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "Pass", cappelletti)
+	})
+
+	t.Run("Cappelletti: shows the minor", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (5): A Q J 3 2 -> 7 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "3D", cappelletti)
+	})
+
+	t.Run("Cappelletti: preference with equal majors", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): 5 4 3
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): Q 3 2 -> 2 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2H", cappelletti)
+	})
+
+	// DONT
+	t.Run("DONT: one-suiter", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Nine}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): A 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "X", dont)
+	})
+
+	t.Run("DONT: spades", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): A 4 -> 4 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2S", dont)
+	})
+
+	t.Run("DONT: both majors", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K Q 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (5): A J 8 7 6 -> 5 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six},
+			// Diamonds (2): 5 4
+			{Suit: Diamonds, Rank: Five}, {Suit: Diamonds, Rank: Four},
+			// Clubs (2): 3 2
+			{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2H", dont)
+	})
+
+	t.Run("DONT: diamonds and a major", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K Q 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (5): A J 8 7 6 -> 5 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2D", dont)
+	})
+
+	t.Run("DONT: clubs and a higher suit", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K Q 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (3): 4 3 2
+			{Suit: Diamonds, Rank: Four}, {Suit: Diamonds, Rank: Three}, {Suit: Diamonds, Rank: Two},
+			// Clubs (5): A J 8 7 6 -> 5 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Eight}, {Suit: Clubs, Rank: Seven}, {Suit: Clubs, Rank: Six},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "2C", dont)
+	})
+
+	t.Run("DONT: no penalty double", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): A 4 3 -> 4 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+
+		expectCall(t, bidder, auction, "Pass", dont)
+	})
+
+	t.Run("DONT: relay after the double", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): 5 4 3
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): Q 3 2 -> 2 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2C", dont)
+	})
+
+	t.Run("DONT: shows the suit", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Nine}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): A 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Double: true, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: Clubs, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "2H", dont)
+	})
+
+	t.Run("DONT: pass or correct", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): Q 4 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (4): K 4 3 2 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (1): 5
+			{Suit: Diamonds, Rank: Five},
+			// Clubs (4): 8 7 6 5
+			{Suit: Clubs, Rank: Eight}, {Suit: Clubs, Rank: Seven}, {Suit: Clubs, Rank: Six}, {Suit: Clubs, Rank: Five},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2H", dont)
+	})
+
+	t.Run("DONT: corrects to spades", func(t *testing.T) {
+		bidder := NewPlayer(East)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): K Q 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (5): A J 8 7 6 -> 5 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: West})
+		auction.AddBid(Bid{Pass: true, Position: North})
+
+		expectCall(t, bidder, auction, "2S", dont)
+	})
+
+	t.Run("DONT: preference for spades", func(t *testing.T) {
+		bidder := NewPlayer(West)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): Q 4 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		expectCall(t, bidder, auction, "2S", dont)
+	})
+}
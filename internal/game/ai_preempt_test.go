@@ -0,0 +1,790 @@
+package game
+
+import "testing"
+
+// TestAI_Preempts checks Polish Club's weak twos, preempts and the Multi
+// 2♦, with responder's raises, new suits and asks.
+func TestAI_Preempts(t *testing.T) {
+	// Openings, which depend on the vulnerability
+	t.Run("Weak two in spades", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q 9 8 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): Q 7 6 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "2S", PolishClub)
+	})
+
+	t.Run("Weak two in hearts", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (6): A Q 9 8 3 2 -> 6 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Nine}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "2H", PolishClub)
+	})
+
+	t.Run("Weak two in diamonds", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (3): 4 3 2
+			{Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (6): A Q 9 8 3 2 -> 6 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Nine}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Three}, {Suit: Diamonds, Rank: Two},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "2D", PolishClub)
+	})
+
+	t.Run("Poor suit, vulnerable", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K J 9 8 3 2 -> 4 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): Q 7 6 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "Pass", PolishClub)
+	})
+
+	t.Run("Poor suit, not vulnerable", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K J 9 8 3 2 -> 4 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): Q 7 6 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+
+		expectCall(t, bidder, auction, "2S", PolishClub)
+	})
+
+	t.Run("5 HCP, vulnerable", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q 9 8 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNorthSouth
+
+		expectCall(t, bidder, auction, "Pass", PolishClub)
+	})
+
+	t.Run("5 HCP, not vulnerable", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q 9 8 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulEastWest
+
+		expectCall(t, bidder, auction, "2S", PolishClub)
+	})
+
+	t.Run("No weak two with four of the other major", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q 9 8 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (4): Q 5 4 2 -> 2 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Two},
+			// Diamonds (2): 7 6
+			{Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (1): 4
+			{Suit: Clubs, Rank: Four},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+
+		expectCall(t, bidder, auction, "Pass", PolishClub)
+	})
+
+	t.Run("3-level preempt", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (7): K Q J 9 8 3 2 -> 6 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Nine}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (2): 7 6
+			{Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "3H", PolishClub)
+	})
+
+	t.Run("3-level preempt in clubs", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (1): 4
+			{Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (7): K Q J 9 8 3 2 -> 6 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Nine}, {Suit: Clubs, Rank: Eight}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "3C", PolishClub)
+	})
+
+	t.Run("4-level preempt", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (8): K Q J 9 8 4 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (2): 7 6
+			{Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "4S", PolishClub)
+	})
+
+	t.Run("Multi with hearts", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (6): A Q 9 8 3 2 -> 6 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Nine}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "2D", PolishClubMulti)
+	})
+
+	t.Run("Multi with spades", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K J 9 8 3 2 -> 4 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): Q 7 6 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+
+		expectCall(t, bidder, auction, "2D", PolishClubMulti)
+	})
+
+	t.Run("No weak two in diamonds with the Multi", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (3): 4 3 2
+			{Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (6): A Q 9 8 3 2 -> 6 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Nine}, {Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Three}, {Suit: Diamonds, Rank: Two},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulBoth
+
+		expectCall(t, bidder, auction, "Pass", PolishClubMulti)
+	})
+
+	// Responder's calls
+	t.Run("Raise to block with four trumps", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): A 4 3 2 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (4): 5 4 3 2
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "4S", PolishClub)
+	})
+
+	t.Run("Raise to block with three trumps", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A 4 3 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (4): 5 4 3 2
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): 8 7 6 5
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "3S", PolishClub)
+	})
+
+	t.Run("Ogust ask", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): A 4 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four},
+			// Hearts (4): A K 3 2 -> 7 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K Q 6 5 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "2NT", PolishClub)
+	})
+
+	t.Run("New suit forcing", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (1): 4
+			{Suit: Spades, Rank: Four},
+			// Hearts (6): A K J 8 3 2 -> 8 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): K Q 6 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "3H", PolishClub)
+	})
+
+	t.Run("Pass of a weak two", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 4 3
+			{Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (4): 5 4 3 2
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): 8 7 6 5
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "Pass", PolishClub)
+	})
+
+	t.Run("Raise of a preempt", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A 4 3 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): Q 3 2 -> 2 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): 8 7 6 5
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 3, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "4H", PolishClub)
+	})
+
+	// Opener's answers to Ogust and to a new suit, and responder's last word
+	t.Run("Ogust: minimum, good suit", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q 9 8 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "3D", PolishClub)
+	})
+
+	t.Run("Ogust: minimum, poor suit", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K J 9 8 3 2 -> 4 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): Q 7 6 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "3C", PolishClub)
+	})
+
+	t.Run("Ogust: maximum, good suit", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): A Q 9 8 3 2 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "3S", PolishClub)
+	})
+
+	t.Run("Ogust: maximum, poor suit", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K J 9 8 3 2 -> 4 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K Q 6 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "3H", PolishClub)
+	})
+
+	t.Run("Ogust: AKQ", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): A K Q 8 3 2 -> 9 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "3NT", PolishClub)
+	})
+
+	t.Run("Game opposite a maximum", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): A 4 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four},
+			// Hearts (4): A K 3 2 -> 7 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K Q 6 5 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+		auction.AddBid(Bid{Level: 3, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "4S", PolishClub)
+	})
+
+	t.Run("Sign-off opposite a minimum", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): A 4 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four},
+			// Hearts (4): A K 3 2 -> 7 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K Q 6 5 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+		auction.AddBid(Bid{Level: 3, Strain: Clubs, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "3S", PolishClub)
+	})
+
+	t.Run("Support for the new suit", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q 9 8 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (3): A 5 4 -> 4 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (2): 7 6
+			{Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 3, Strain: Hearts, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "4H", PolishClub)
+	})
+
+	t.Run("No support for the new suit", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q 9 8 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): A 7 6 -> 4 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Spades, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 3, Strain: Hearts, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "3S", PolishClub)
+	})
+
+	// The Multi 2♦
+	t.Run("Multi: pass or correct", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 4 3
+			{Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (2): 5 2
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Two},
+			// Diamonds (5): A K 7 6 5 -> 7 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (4): 5 4 3 2
+			{Suit: Clubs, Rank: Five}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "2H", PolishClubMulti)
+	})
+
+	t.Run("Multi: to play in spades", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (4): A 4 3 2 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (1): 5
+			{Suit: Hearts, Rank: Five},
+			// Diamonds (4): 8 7 6 5
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (4): 5 4 3 2
+			{Suit: Clubs, Rank: Five}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "2S", PolishClubMulti)
+	})
+
+	t.Run("Multi: raise to block", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (3): A 4 3 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): Q 3 2 -> 2 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): 8 7 6 5
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "3H", PolishClubMulti)
+	})
+
+	t.Run("Multi: ask", func(t *testing.T) {
+		bidder := NewPlayer(South)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): A 4 -> 4 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Four},
+			// Hearts (4): A K 3 2 -> 7 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (4): K Q 6 5 -> 5 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		expectCall(t, bidder, auction, "2NT", PolishClubMulti)
+	})
+
+	t.Run("Multi: correction to spades", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): K Q 9 8 3 2 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "2S", PolishClubMulti)
+	})
+
+	t.Run("Multi: pass with hearts", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (6): A Q 9 8 3 2 -> 6 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Nine}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "Pass", PolishClubMulti)
+	})
+
+	t.Run("Multi: minimum with hearts", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (2): 5 4
+			{Suit: Spades, Rank: Five}, {Suit: Spades, Rank: Four},
+			// Hearts (6): A Q 9 8 3 2 -> 6 HCP
+			{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Nine}, {Suit: Hearts, Rank: Eight}, {Suit: Hearts, Rank: Three}, {Suit: Hearts, Rank: Two},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "3C", PolishClubMulti)
+	})
+
+	t.Run("Multi: maximum with spades", func(t *testing.T) {
+		bidder := NewPlayer(North)
+		bidder.Hand = NewHand([]Card{
+			// Spades (6): A Q 9 8 3 2 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): K 7 6 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.Vulnerability = VulNone
+		auction.AddBid(Bid{Level: 2, Strain: Diamonds, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: NoTrump, Position: South})
+		auction.AddBid(Bid{Pass: true, Position: West})
+
+		expectCall(t, bidder, auction, "3S", PolishClubMulti)
+	})
+}
@@ -0,0 +1,94 @@
+package game
+
+import "testing"
+
+// TestSAYC checks SAYC's openings, responses and conventions. Calls are
+// made in turn from North, and the player is the next to call.
+func TestSAYC(t *testing.T) {
+	tests := []struct {
+		name    string
+		auction string
+		hand    string
+		want    string
+	}{
+		{"1NT opening", "", "AQ32.K54.K76.A43", "1NT"},
+		{"2C opening", "", "AKQ2.AK4.AK6.K43", "2C"},
+		{"Weak two", "", "KQJ976.54.876.43", "2S"},
+		{"Preempt", "", "2.KQJ9876.876.43", "3H"},
+		{"No opening below 12 HCP", "", "AQ3.K5432.76.Q43", "Pass"},
+		{"Longer minor", "", "AK32.K54.76.Q432", "1C"},
+		{"4-4 minors open 1D", "", "A2.K54.KJ76.Q432", "1D"},
+		{"5-5 majors open 1S", "", "AKJ32.KQ432.5.2", "1S"},
+
+		{"Jacoby 2NT", "1H Pass", "K2.AQ43.KJ65.Q32", "2NT"},
+		{"Limit raise", "1H Pass", "K2.Q43.KJ65.Q432", "3H"},
+		{"Simple raise", "1H Pass", "K2.Q43.J765.J432", "2H"},
+		{"New major at the 1 level", "1H Pass", "KJ32.43.K765.Q43", "1S"},
+		{"New suit at the 2 level", "1S Pass", "Q2.K4.AJ765.Q432", "2D"},
+		{"1NT over a major", "1S Pass", "Q2.K43.J765.Q432", "1NT"},
+		{"1H over a minor", "1D Pass", "K2.Q432.J765.Q43", "1H"},
+		{"Jacoby transfer", "1NT Pass", "J8765.43.K76.432", "2H"},
+		{"Stayman", "1NT Pass", "KJ32.Q4.K765.432", "2C"},
+		{"Invitation over 1NT", "1NT Pass", "K32.Q4.K765.5432", "2NT"},
+		{"2D waiting", "2C Pass", "432.5432.J32.432", "2D"},
+		{"Raise of a weak two", "2H Pass", "A2.K543.Q876.432", "4H"},
+
+		{"Transfer completed", "1NT Pass 2D Pass", "AQ3.K54.K76.A432", "2H"},
+		{"Super-accept", "1NT Pass 2D Pass", "AQ3.KJ54.K76.A43", "3H"},
+		{"Stayman reply", "1NT Pass 2C Pass", "AQ32.K54.K76.A43", "2S"},
+		{"Shortness after Jacoby 2NT", "1S Pass 2NT Pass", "AKJ32.K543.5.Q32", "3D"},
+		{"Minimum after Jacoby 2NT", "1S Pass 2NT Pass", "AJ432.K54.Q76.Q3", "4S"},
+		{"Opener's 1NT rebid", "1C Pass 1H Pass", "AQ3.J4.K765.Q432", "1NT"},
+		{"Raise of responder's major", "1C Pass 1H Pass", "A3.KJ54.K76.Q432", "2H"},
+		{"Pass of 1NT with a balanced minimum", "1S Pass 1NT Pass", "AKJ32.K54.Q76.43", "Pass"},
+
+		{"RKCB reply", "1S Pass 3S Pass 4NT Pass", "K432.A54.Q76.J32", "5H"},
+		{"Quantitative 4NT declined", "1NT Pass 4NT Pass", "AQ3.K54.K76.K432", "Pass"},
+		{"Quantitative 4NT accepted", "1NT Pass 4NT Pass", "AQ3.K54.KJ6.A432", "6NT"},
+		{"Slam with one key card missing", "1S Pass 3S Pass 4NT Pass 5C Pass", "AKJ32.A4.K76.K32", "6S"},
+		{"Sign-off with two key cards missing", "1S Pass 3S Pass 4NT Pass 5D Pass", "AKJ32.A4.K76.K32", "5S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := systemAuction(t, tt.auction)
+			p := systemPlayer(t, Position(len(a.Bids)%4), tt.hand)
+			p.System = SAYC
+			if got := p.MakeBid(a).String(); got != tt.want {
+				t.Errorf("after %q with %s = %s, want %s", tt.auction, tt.hand, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTwoOverOne checks where 2/1 Game Forcing differs from SAYC.
+func TestTwoOverOne(t *testing.T) {
+	tests := []struct {
+		name    string
+		auction string
+		hand    string
+		sayc    string
+		twoOne  string
+	}{
+		{"12 HCP without support", "1S Pass", "Q2.K4.AJ765.Q432", "2D", "1NT"},
+		{"2/1 with 13 HCP", "1S Pass", "Q2.K4.AJ765.K432", "2D", "2D"},
+		{"10 HCP with clubs", "1H Pass", "Q32.4.A765.KJ432", "2C", "1NT"},
+		{"Balanced minimum over 1NT", "1S Pass 1NT Pass", "AKJ32.K54.Q76.43", "Pass", "2D"},
+		{"Six-card suit over 1NT", "1S Pass 1NT Pass", "AKJ432.K54.Q7.43", "2S", "2S"},
+		{"Game after 2/1", "1S Pass 2D Pass 2S Pass", "Q2.K4.AJ765.K432", "3NT", "3NT"},
+		{"Shared opening", "", "AQ32.K54.K76.A43", "1NT", "1NT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := systemAuction(t, tt.auction)
+			p := systemPlayer(t, Position(len(a.Bids)%4), tt.hand)
+			p.System = SAYC
+			if got := p.MakeBid(a).String(); got != tt.sayc {
+				t.Errorf("SAYC after %q with %s = %s, want %s", tt.auction, tt.hand, got, tt.sayc)
+			}
+			p.System = TwoOverOne
+			if got := p.MakeBid(a).String(); got != tt.twoOne {
+				t.Errorf("2/1 after %q with %s = %s, want %s", tt.auction, tt.hand, got, tt.twoOne)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package game
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // Bid represents a single bid in the auction
 type Bid struct {
@@ -10,6 +13,8 @@ type Bid struct {
 	Pass     bool     // True if this is a pass
 	Double   bool     // True if this is a double
 	Redouble bool     // True if this is a redouble
+
+	Explanation *Explanation // What the call shows, when a bidding system's rule made it
 }
 
 // NewPass creates a new pass bid
@@ -58,7 +63,11 @@ func (b Bid) String() string {
 
 // Auction represents the bidding sequence
 type Auction struct {
-	Bids []Bid
+	Bids          []Bid
+	Vulnerability BoardVulnerability // Who is vulnerable; the computer bids with it in mind
+
+	pictures [4]HandPicture // What each seat has shown, from the first inferred bids
+	inferred int
 }
 
 // NewAuction creates a new auction
@@ -68,9 +77,11 @@ func NewAuction() *Auction {
 	}
 }
 
-// AddBid adds a bid to the auction
+// AddBid adds a bid to the auction and narrows the picture of the
+// bidder's hand with what the bid shows.
 func (a *Auction) AddBid(bid Bid) {
 	a.Bids = append(a.Bids, bid)
+	a.infer()
 }
 
 // LastNonPassBid returns the last bid that wasn't a pass
@@ -83,22 +94,122 @@ func (a *Auction) LastNonPassBid() (Bid, bool) {
 	return Bid{}, false
 }
 
+// Errors describing why a call is not allowed at this point of the auction.
+var (
+	ErrAuctionClosed     = errors.New("the auction is already over")
+	ErrInvalidLevel      = errors.New("bid level must be between 1 and 7")
+	ErrInvalidStrain     = errors.New("bid strain must be C, D, H, S or NT")
+	ErrInsufficientBid   = errors.New("must be higher than the previous bid")
+	ErrNothingToDouble   = errors.New("there is no opposing bid to double")
+	ErrAlreadyDoubled    = errors.New("the last bid has already been doubled")
+	ErrNothingToRedouble = errors.New("there is no opposing double to redouble")
+)
+
+// IllegalCallError reports a call that breaks the rules of the auction.
+// Reason is one of the Err* values above, so callers can use errors.Is.
+type IllegalCallError struct {
+	Call   Bid
+	Reason error
+}
+
+// Error implements the error interface.
+func (e *IllegalCallError) Error() string {
+	return fmt.Sprintf("illegal call %s: %v", e.Call, e.Reason)
+}
+
+// Unwrap returns the underlying reason.
+func (e *IllegalCallError) Unwrap() error {
+	return e.Reason
+}
+
 // IsValidBid checks if a bid is valid given the current auction state
 func (a *Auction) IsValidBid(bid Bid) bool {
-	if bid.Pass || bid.Double || bid.Redouble {
-		return true
+	return a.ValidateBid(bid) == nil
+}
+
+// ValidateBid checks a call made by the player next to act against the
+// Laws of Duplicate Bridge and returns an *IllegalCallError if it is not
+// allowed. Sides are worked out from the order of the calls, so the
+// positions stored on earlier bids do not need to be set.
+func (a *Auction) ValidateBid(bid Bid) error {
+	if a.IsAuctionComplete() || a.IsPassedOut() {
+		return &IllegalCallError{Call: bid, Reason: ErrAuctionClosed}
+	}
+
+	switch {
+	case bid.Pass:
+		return nil
+	case bid.Double:
+		// Only the last contract bid may be doubled, it must belong to the
+		// opponents and it must not already be doubled or redoubled.
+		idx := a.lastContractIndex()
+		if idx < 0 || !a.isOpponentCall(idx) {
+			return &IllegalCallError{Call: bid, Reason: ErrNothingToDouble}
+		}
+		for _, b := range a.Bids[idx+1:] {
+			if b.Double || b.Redouble {
+				return &IllegalCallError{Call: bid, Reason: ErrAlreadyDoubled}
+			}
+		}
+		return nil
+	case bid.Redouble:
+		// A redouble answers a double made by the opponents of our contract.
+		idx := a.lastContractIndex()
+		if idx < 0 {
+			return &IllegalCallError{Call: bid, Reason: ErrNothingToRedouble}
+		}
+		for i := len(a.Bids) - 1; i > idx; i-- {
+			b := a.Bids[i]
+			if b.Redouble {
+				return &IllegalCallError{Call: bid, Reason: ErrAlreadyDoubled}
+			}
+			if b.Double {
+				if !a.isOpponentCall(i) {
+					return &IllegalCallError{Call: bid, Reason: ErrNothingToRedouble}
+				}
+				return nil
+			}
+		}
+		return &IllegalCallError{Call: bid, Reason: ErrNothingToRedouble}
+	}
+
+	if bid.Level < 1 || bid.Level > 7 {
+		return &IllegalCallError{Call: bid, Reason: ErrInvalidLevel}
+	}
+	if bid.Strain < Clubs || bid.Strain > NoTrump {
+		return &IllegalCallError{Call: bid, Reason: ErrInvalidStrain}
 	}
 
 	lastBid, found := a.LastNonPassBid()
 	if !found {
-		return true // First bid is always valid
+		return nil // First bid is always valid
 	}
 
 	// Calculate bid values for comparison
 	bidValue := bid.Level*5 + int(bid.Strain)
 	lastBidValue := lastBid.Level*5 + int(lastBid.Strain)
 
-	return bidValue > lastBidValue
+	if bidValue <= lastBidValue {
+		return &IllegalCallError{Call: bid, Reason: ErrInsufficientBid}
+	}
+	return nil
+}
+
+// lastContractIndex returns the index of the last contract bid, or -1.
+func (a *Auction) lastContractIndex() int {
+	for i := len(a.Bids) - 1; i >= 0; i-- {
+		b := a.Bids[i]
+		if !b.Pass && !b.Double && !b.Redouble {
+			return i
+		}
+	}
+	return -1
+}
+
+// isOpponentCall reports whether the call at index i was made by an
+// opponent of the player who is next to call.
+func (a *Auction) isOpponentCall(i int) bool {
+	return (len(a.Bids)-i)%2 == 1
 }
 
 // IsAuctionComplete checks if the auction is complete
@@ -124,3 +235,22 @@ func (a *Auction) IsAuctionComplete() bool {
 
 	return false
 }
+
+// IsPassedOut reports whether all four players passed without a bid.
+func (a *Auction) IsPassedOut() bool {
+	if len(a.Bids) < 4 {
+		return false
+	}
+	for _, bid := range a.Bids[:4] {
+		if !bid.Pass {
+			return false
+		}
+	}
+	return true
+}
+
+// IsOver reports whether no further calls can be made, either because a
+// contract has been reached or because the deal was passed out.
+func (a *Auction) IsOver() bool {
+	return a.IsAuctionComplete() || a.IsPassedOut()
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -41,6 +42,72 @@ func TestAuction_IsValidBid(t *testing.T) {
 			bid:     NewPass(),
 			want:    true,
 		},
+		{
+			name:    "Double of opponent's bid is valid",
+			auction: &Auction{Bids: []Bid{NewBid(1, Hearts)}},
+			bid:     NewDouble(),
+			want:    true,
+		},
+		{
+			name:    "Double of partner's bid is invalid",
+			auction: &Auction{Bids: []Bid{NewBid(1, Hearts), NewPass()}},
+			bid:     NewDouble(),
+			want:    false,
+		},
+		{
+			name:    "Double after two passes is valid",
+			auction: &Auction{Bids: []Bid{NewBid(1, Hearts), NewPass(), NewPass()}},
+			bid:     NewDouble(),
+			want:    true,
+		},
+		{
+			name:    "Double with no bid is invalid",
+			auction: &Auction{Bids: []Bid{NewPass()}},
+			bid:     NewDouble(),
+			want:    false,
+		},
+		{
+			name:    "Second double is invalid",
+			auction: &Auction{Bids: []Bid{NewBid(1, Hearts), NewDouble(), NewPass()}},
+			bid:     NewDouble(),
+			want:    false,
+		},
+		{
+			name:    "Redouble of opponent's double is valid",
+			auction: &Auction{Bids: []Bid{NewBid(1, Hearts), NewDouble()}},
+			bid:     NewRedouble(),
+			want:    true,
+		},
+		{
+			name:    "Redouble of partner's double is invalid",
+			auction: &Auction{Bids: []Bid{NewBid(1, Hearts), NewDouble(), NewPass()}},
+			bid:     NewRedouble(),
+			want:    false,
+		},
+		{
+			name:    "Redouble without a double is invalid",
+			auction: &Auction{Bids: []Bid{NewBid(1, Hearts), NewPass()}},
+			bid:     NewRedouble(),
+			want:    false,
+		},
+		{
+			name:    "Double of a new bid after a redouble is valid",
+			auction: &Auction{Bids: []Bid{NewBid(1, Hearts), NewDouble(), NewRedouble(), NewBid(2, Clubs)}},
+			bid:     NewDouble(),
+			want:    true,
+		},
+		{
+			name:    "No calls after the auction is complete",
+			auction: &Auction{Bids: []Bid{NewBid(1, Clubs), NewPass(), NewPass(), NewPass()}},
+			bid:     NewPass(),
+			want:    false,
+		},
+		{
+			name:    "Level eight is invalid",
+			auction: NewAuction(),
+			bid:     NewBid(8, Clubs),
+			want:    false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -52,6 +119,35 @@ func TestAuction_IsValidBid(t *testing.T) {
 	}
 }
 
+func TestAuction_ValidateBidErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		auction *Auction
+		bid     Bid
+		want    error
+	}{
+		{"Insufficient", &Auction{Bids: []Bid{NewBid(2, Hearts)}}, NewBid(2, Diamonds), ErrInsufficientBid},
+		{"Double partner", &Auction{Bids: []Bid{NewBid(1, Spades), NewPass()}}, NewDouble(), ErrNothingToDouble},
+		{"Double twice", &Auction{Bids: []Bid{NewBid(1, Spades), NewDouble(), NewPass()}}, NewDouble(), ErrAlreadyDoubled},
+		{"Redouble undoubled", &Auction{Bids: []Bid{NewBid(1, Spades), NewPass()}}, NewRedouble(), ErrNothingToRedouble},
+		{"Redouble twice", &Auction{Bids: []Bid{NewBid(1, Spades), NewDouble(), NewRedouble()}}, NewRedouble(), ErrAlreadyDoubled},
+		{"Passed out", &Auction{Bids: []Bid{NewPass(), NewPass(), NewPass(), NewPass()}}, NewBid(1, Clubs), ErrAuctionClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.auction.ValidateBid(tt.bid)
+			var illegal *IllegalCallError
+			if !errors.As(err, &illegal) {
+				t.Fatalf("ValidateBid() error = %v, want *IllegalCallError", err)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ValidateBid() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestAuction_IsAuctionComplete(t *testing.T) {
 	tests := []struct {
 		name    string
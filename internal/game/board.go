@@ -0,0 +1,85 @@
+package game
+
+import "fmt"
+
+// BoardVulnerability says which sides are vulnerable on a board.
+type BoardVulnerability int
+
+const (
+	VulNone BoardVulnerability = iota
+	VulNorthSouth
+	VulEastWest
+	VulBoth
+)
+
+// String returns the string representation of a BoardVulnerability.
+func (v BoardVulnerability) String() string {
+	switch v {
+	case VulNorthSouth:
+		return "N-S"
+	case VulEastWest:
+		return "E-W"
+	case VulBoth:
+		return "Both"
+	default:
+		return "None"
+	}
+}
+
+// IsVulnerable returns the vulnerability of the given side.
+func (v BoardVulnerability) IsVulnerable(side Side) Vulnerability {
+	switch v {
+	case VulBoth:
+		return Vulnerable
+	case VulNorthSouth:
+		return Vulnerability(side == NorthSouth)
+	case VulEastWest:
+		return Vulnerability(side == EastWest)
+	default:
+		return NotVulnerable
+	}
+}
+
+// boardVulnerability is the standard vulnerability cycle of a set of 16
+// duplicate boards, indexed by (board number - 1) % 16.
+var boardVulnerability = [16]BoardVulnerability{
+	VulNone, VulNorthSouth, VulEastWest, VulBoth,
+	VulNorthSouth, VulEastWest, VulBoth, VulNone,
+	VulEastWest, VulBoth, VulNone, VulNorthSouth,
+	VulBoth, VulNone, VulNorthSouth, VulEastWest,
+}
+
+// Board is a numbered duplicate board. The number fixes the dealer and the
+// vulnerability, following the standard 16-board cycle.
+type Board struct {
+	Number        int
+	Dealer        Position
+	Vulnerability BoardVulnerability
+}
+
+// NewBoard returns the board with the given number (1 or higher).
+func NewBoard(number int) Board {
+	if number < 1 {
+		number = 1
+	}
+	return Board{
+		Number:        number,
+		Dealer:        Position((number - 1) % 4),
+		Vulnerability: boardVulnerability[(number-1)%16],
+	}
+}
+
+// Next returns the following board.
+func (b Board) Next() Board {
+	return NewBoard(b.Number + 1)
+}
+
+// Vulnerable returns the vulnerability of the given player's side.
+func (b Board) Vulnerable(p Position) Vulnerability {
+	return b.Vulnerability.IsVulnerable(p.Side())
+}
+
+// String returns a short description, e.g. "Board 5 (Dealer North, Vul N-S)".
+func (b Board) String() string {
+	return fmt.Sprintf("Board %d (Dealer %s, Vul %s)", b.Number, b.Dealer, b.Vulnerability)
+}
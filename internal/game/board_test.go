@@ -0,0 +1,44 @@
+package game
+
+import "testing"
+
+func TestNewBoard(t *testing.T) {
+	tests := []struct {
+		number int
+		dealer Position
+		vul    BoardVulnerability
+	}{
+		{1, North, VulNone},
+		{2, East, VulNorthSouth},
+		{3, South, VulEastWest},
+		{4, West, VulBoth},
+		{5, North, VulNorthSouth},
+		{8, West, VulNone},
+		{9, North, VulEastWest},
+		{12, West, VulNorthSouth},
+		{13, North, VulBoth},
+		{16, West, VulEastWest},
+		{17, North, VulNone},
+		{34, East, VulNorthSouth},
+	}
+
+	for _, tt := range tests {
+		b := NewBoard(tt.number)
+		if b.Dealer != tt.dealer || b.Vulnerability != tt.vul {
+			t.Errorf("NewBoard(%d) = %s, want dealer %s vul %s", tt.number, b, tt.dealer, tt.vul)
+		}
+	}
+}
+
+func TestBoard_Vulnerable(t *testing.T) {
+	b := NewBoard(3) // E-W vulnerable
+	if b.Vulnerable(East) != Vulnerable || b.Vulnerable(West) != Vulnerable {
+		t.Error("East-West should be vulnerable on board 3")
+	}
+	if b.Vulnerable(North) != NotVulnerable || b.Vulnerable(South) != NotVulnerable {
+		t.Error("North-South should not be vulnerable on board 3")
+	}
+	if b.Next().Number != 4 {
+		t.Errorf("Next() = %d, want 4", b.Next().Number)
+	}
+}
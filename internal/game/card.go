@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 )
 
 // Suit represents the four suits in a deck of cards
@@ -75,6 +76,14 @@ func (d Deck) Shuffle() {
 	})
 }
 
+// ShuffleWith randomizes the order of cards using the given source, so a
+// seeded source always produces the same order.
+func (d Deck) ShuffleWith(r *rand.Rand) {
+	r.Shuffle(len(d), func(i, j int) {
+		d[i], d[j] = d[j], d[i]
+	})
+}
+
 // Deal deals a specified number of cards from the deck
 func (d *Deck) Deal(n int) []Card {
 	if n > len(*d) {
@@ -84,3 +93,21 @@ func (d *Deck) Deal(n int) []Card {
 	*d = (*d)[n:]
 	return cards
 }
+
+// ParseCard reads a card such as "AS", "10h", "TD" or "S7". The rank and
+// suit may be given in either order.
+func ParseCard(s string) (Card, error) {
+	in := strings.ToUpper(strings.TrimSpace(s))
+	in = strings.ReplaceAll(in, "10", "T")
+	if len(in) != 2 {
+		return Card{}, fmt.Errorf("invalid card %q", s)
+	}
+	for _, pair := range [][2]byte{{in[0], in[1]}, {in[1], in[0]}} {
+		r, ok := rankFromChar(pair[0])
+		suit := strings.IndexByte("CDHS", pair[1])
+		if ok && suit >= 0 {
+			return Card{Suit: Suit(suit), Rank: r}, nil
+		}
+	}
+	return Card{}, fmt.Errorf("invalid card %q", s)
+}
@@ -45,3 +45,25 @@ func TestDeck_Shuffle(t *testing.T) {
 		t.Error("Shuffled deck is identical to a new deck")
 	}
 }
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Card
+		ok   bool
+	}{
+		{"AS", Card{Spades, Ace}, true},
+		{"10h", Card{Hearts, Ten}, true},
+		{"TD", Card{Diamonds, Ten}, true},
+		{"c7", Card{Clubs, Seven}, true},
+		{"1S", Card{}, false},
+		{"AX", Card{}, false},
+		{"", Card{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseCard(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseCard(%q) = %v, %v; want %v, ok=%v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,409 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// ErrNoMatchingDeal is returned when the generator gives up before finding
+// a deal that satisfies every constraint.
+var ErrNoMatchingDeal = errors.New("no deal found matching the constraints")
+
+// DefaultMaxAttempts is how many random deals a DealGenerator tries for
+// each matching deal before giving up.
+const DefaultMaxAttempts = 1000000
+
+// Range is an inclusive range of integers. In JSON it is written as a
+// two-element array, e.g. [15, 17].
+type Range struct {
+	Min int
+	Max int
+}
+
+// Contains reports whether v lies within the range.
+func (r Range) Contains(v int) bool {
+	return v >= r.Min && v <= r.Max
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int{r.Min, r.Max})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	var v [2]int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("range must be [min, max]: %w", err)
+	}
+	if v[0] > v[1] {
+		return fmt.Errorf("range [%d, %d] is empty", v[0], v[1])
+	}
+	r.Min, r.Max = v[0], v[1]
+	return nil
+}
+
+// FloatRange is an inclusive range of numbers that may have fractions,
+// such as quick tricks. In JSON it is written as Range is, e.g. [1.5, 8].
+type FloatRange struct {
+	Min float64
+	Max float64
+}
+
+// Contains reports whether v lies within the range.
+func (r FloatRange) Contains(v float64) bool {
+	return v >= r.Min && v <= r.Max
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r FloatRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{r.Min, r.Max})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *FloatRange) UnmarshalJSON(data []byte) error {
+	var v [2]float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("range must be [min, max]: %w", err)
+	}
+	if v[0] > v[1] {
+		return fmt.Errorf("range [%g, %g] is empty", v[0], v[1])
+	}
+	r.Min, r.Max = v[0], v[1]
+	return nil
+}
+
+// SeatConstraint restricts the hand dealt to one seat. Nil fields are not
+// checked. Predicate is a hook for conditions the other fields can't
+// express; it is only called once all the other checks pass.
+type SeatConstraint struct {
+	HCP       *Range           `json:"hcp,omitempty"`
+	Spades    *Range           `json:"spades,omitempty"`
+	Hearts    *Range           `json:"hearts,omitempty"`
+	Diamonds  *Range           `json:"diamonds,omitempty"`
+	Clubs     *Range           `json:"clubs,omitempty"`
+	Balanced  *bool            `json:"balanced,omitempty"`
+	Predicate func(*Hand) bool `json:"-"`
+}
+
+// suitRange returns the length constraint for a suit.
+func (c *SeatConstraint) suitRange(s Suit) *Range {
+	switch s {
+	case Spades:
+		return c.Spades
+	case Hearts:
+		return c.Hearts
+	case Diamonds:
+		return c.Diamonds
+	default:
+		return c.Clubs
+	}
+}
+
+// setSuitRange sets the length constraint for a suit.
+func (c *SeatConstraint) setSuitRange(s Suit, r *Range) {
+	switch s {
+	case Spades:
+		c.Spades = r
+	case Hearts:
+		c.Hearts = r
+	case Diamonds:
+		c.Diamonds = r
+	default:
+		c.Clubs = r
+	}
+}
+
+// matches checks a 13-card holding against the constraint. It counts
+// points and suit lengths directly so that it stays cheap when called
+// millions of times.
+func (c *SeatConstraint) matches(cards []Card) bool {
+	var hcp int
+	var lengths [4]int
+	for _, card := range cards {
+		if card.Rank >= Jack {
+			hcp += int(card.Rank - Ten)
+		}
+		lengths[card.Suit]++
+	}
+	if !c.fits(hcp, lengths) {
+		return false
+	}
+	if c.Predicate != nil && !c.Predicate(NewHand(cards)) {
+		return false
+	}
+	return true
+}
+
+// fits checks a hand's points and suit lengths against every field of the
+// constraint but Predicate.
+func (c *SeatConstraint) fits(hcp int, lengths [4]int) bool {
+	if c.HCP != nil && !c.HCP.Contains(hcp) {
+		return false
+	}
+	for s := Clubs; s <= Spades; s++ {
+		if r := c.suitRange(s); r != nil && !r.Contains(lengths[s]) {
+			return false
+		}
+	}
+	if c.Balanced != nil && isBalancedShape(lengths) != *c.Balanced {
+		return false
+	}
+	return true
+}
+
+// isBalancedShape applies the IsBalanced rule to a set of suit lengths.
+func isBalancedShape(lengths [4]int) bool {
+	doubletons := 0
+	for _, n := range lengths {
+		if n < 2 {
+			return false
+		}
+		if n == 2 {
+			doubletons++
+		}
+	}
+	return doubletons <= 1
+}
+
+// DealConstraints holds an optional constraint for each seat, indexed by Position.
+type DealConstraints [4]*SeatConstraint
+
+// Matches reports whether every hand of the deal satisfies its seat's constraint.
+func (dc DealConstraints) Matches(d Deal) bool {
+	for p, c := range dc {
+		if c != nil && !c.matches(d.Hands[p].Cards) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate reports constraints no deal can meet: more HCP or cards asked
+// for than the deck holds, or fewer than it must deal. The error wraps
+// ErrNoMatchingDeal.
+func (dc DealConstraints) Validate() error {
+	hcpMin, hcpMax, hcpAll := 0, 0, true
+	var suitMin, suitMax [4]int
+	var suitAll [4]bool
+	for s := range suitAll {
+		suitAll[s] = true
+	}
+	for p, c := range dc {
+		if c == nil {
+			hcpAll = false
+			for s := range suitAll {
+				suitAll[s] = false
+			}
+			continue
+		}
+		if c.HCP == nil {
+			hcpAll = false
+		} else {
+			if c.HCP.Min > 37 {
+				return fmt.Errorf("%w: %s can hold at most 37 HCP", ErrNoMatchingDeal, Position(p))
+			}
+			hcpMin += c.HCP.Min
+			hcpMax += c.HCP.Max
+		}
+		seatMin, seatMax := 0, 0
+		for s := Clubs; s <= Spades; s++ {
+			lo, hi := 0, 13
+			if r := c.suitRange(s); r != nil {
+				lo, hi = r.Min, min(r.Max, 13)
+				suitMin[s] += lo
+				suitMax[s] += hi
+			} else {
+				suitAll[s] = false
+			}
+			seatMin += lo
+			seatMax += hi
+		}
+		if seatMin > 13 || seatMax < 13 {
+			return fmt.Errorf("%w: %s's suit lengths can't add up to 13", ErrNoMatchingDeal, Position(p))
+		}
+	}
+	if hcpMin > 40 || hcpAll && hcpMax < 40 {
+		return fmt.Errorf("%w: the seats' HCP can't add up to 40", ErrNoMatchingDeal)
+	}
+	for s := Clubs; s <= Spades; s++ {
+		if suitMin[s] > 13 || suitAll[s] && suitMax[s] < 13 {
+			return fmt.Errorf("%w: the seats' %s can't add up to 13", ErrNoMatchingDeal, suitNames[s])
+		}
+	}
+	return nil
+}
+
+// DealGenerator produces random deals that satisfy a set of constraints.
+type DealGenerator struct {
+	Constraints DealConstraints
+	MaxAttempts int // Random deals tried per call to Next; DefaultMaxAttempts if zero
+	rng         *rand.Rand
+	deck        Deck
+}
+
+// NewDealGenerator creates a generator. The same seed and constraints
+// always produce the same sequence of deals.
+func NewDealGenerator(seed int64, constraints DealConstraints) *DealGenerator {
+	return &DealGenerator{
+		Constraints: constraints,
+		rng:         rand.New(rand.NewSource(seed)),
+		deck:        NewDeck(),
+	}
+}
+
+// Next returns the next deal matching the constraints. Cards are dealt in
+// blocks of 13 so that a constrained seat can be checked, and the deal
+// rejected, before the other hands are built. Constraints that fail
+// Validate are rejected without dealing, and once ctx is done Next stops
+// with the context's error.
+func (g *DealGenerator) Next(ctx context.Context) (Deal, error) {
+	if err := g.Constraints.Validate(); err != nil {
+		return Deal{}, err
+	}
+	attempts := g.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultMaxAttempts
+	}
+	for i := 0; i < attempts; i++ {
+		if i%4096 == 0 {
+			if err := ctx.Err(); err != nil {
+				return Deal{}, err
+			}
+		}
+		g.deck.ShuffleWith(g.rng)
+		ok := true
+		for p, c := range g.Constraints {
+			if c != nil && !c.matches(g.deck[p*13:(p+1)*13]) {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		var d Deal
+		for p := range d.Hands {
+			d.Hands[p] = NewHand(g.deck[p*13 : (p+1)*13])
+		}
+		return d, nil
+	}
+	return Deal{}, ErrNoMatchingDeal
+}
+
+// ParseDealConstraints reads constraints written in a compact text form,
+// as used on the command line. Seats are separated by ';' and conditions
+// by ','. For example:
+//
+//	north:hcp=15-17,balanced;south:hearts=5+,hcp=0-7
+//
+// Seat names may be abbreviated to their first letter. Conditions are
+// hcp, spades, hearts, diamonds and clubs, each with a range "a-b", a
+// minimum "a+" or an exact value "a", plus "balanced" and "unbalanced".
+func ParseDealConstraints(spec string) (DealConstraints, error) {
+	var dc DealConstraints
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		seat, conds, found := strings.Cut(part, ":")
+		if !found {
+			return dc, fmt.Errorf("constraint %q: missing seat, e.g. north:hcp=15-17", part)
+		}
+		pos, err := parseSeat(seat)
+		if err != nil {
+			return dc, err
+		}
+		c := dc[pos]
+		if c == nil {
+			c = &SeatConstraint{}
+			dc[pos] = c
+		}
+		for _, cond := range strings.Split(conds, ",") {
+			if err := c.parseCondition(strings.TrimSpace(strings.ToLower(cond))); err != nil {
+				return dc, fmt.Errorf("constraint for %s: %w", pos, err)
+			}
+		}
+	}
+	return dc, nil
+}
+
+// parseCondition applies a single condition such as "hcp=15-17" or "balanced".
+func (c *SeatConstraint) parseCondition(cond string) error {
+	switch cond {
+	case "":
+		return nil
+	case "balanced":
+		b := true
+		c.Balanced = &b
+		return nil
+	case "unbalanced":
+		b := false
+		c.Balanced = &b
+		return nil
+	}
+
+	key, value, found := strings.Cut(cond, "=")
+	if !found {
+		return fmt.Errorf("unknown condition %q", cond)
+	}
+	r, err := parseRange(value)
+	if err != nil {
+		return err
+	}
+	switch key {
+	case "hcp":
+		c.HCP = r
+	case "spades", "s":
+		c.setSuitRange(Spades, r)
+	case "hearts", "h":
+		c.setSuitRange(Hearts, r)
+	case "diamonds", "d":
+		c.setSuitRange(Diamonds, r)
+	case "clubs", "c":
+		c.setSuitRange(Clubs, r)
+	default:
+		return fmt.Errorf("unknown condition %q", key)
+	}
+	return nil
+}
+
+// parseRange reads "a-b", "a+" or "a".
+func parseRange(s string) (*Range, error) {
+	var lo, hi string
+	switch {
+	case strings.HasSuffix(s, "+"):
+		lo, hi = strings.TrimSuffix(s, "+"), "40"
+	case strings.Contains(s, "-"):
+		lo, hi, _ = strings.Cut(s, "-")
+	default:
+		lo, hi = s, s
+	}
+	from, err1 := strconv.Atoi(lo)
+	to, err2 := strconv.Atoi(hi)
+	if err1 != nil || err2 != nil || from > to {
+		return nil, fmt.Errorf("invalid range %q", s)
+	}
+	return &Range{Min: from, Max: to}, nil
+}
+
+// parseSeat reads a seat name such as "north" or "n".
+func parseSeat(s string) (Position, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "north", "n":
+		return North, nil
+	case "east", "e":
+		return East, nil
+	case "south", "s":
+		return South, nil
+	case "west", "w":
+		return West, nil
+	}
+	return 0, fmt.Errorf("invalid seat %q", s)
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDealGenerator_Constraints(t *testing.T) {
+	balanced := true
+	var dc DealConstraints
+	// Partner opens 1NT and we hold a five-card major.
+	dc[North] = &SeatConstraint{HCP: &Range{15, 17}, Balanced: &balanced}
+	dc[South] = &SeatConstraint{
+		Hearts:    &Range{5, 13},
+		Predicate: func(h *Hand) bool { return h.SuitCount(Spades) <= 3 },
+	}
+
+	g := NewDealGenerator(1, dc)
+	for i := 0; i < 200; i++ {
+		d, err := g.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		north := d.Hands[North]
+		hcp, _ := north.Evaluate()
+		if hcp < 15 || hcp > 17 || !north.IsBalanced() {
+			t.Fatalf("North has %d HCP, balanced %v", hcp, north.IsBalanced())
+		}
+		south := d.Hands[South]
+		if south.SuitCount(Hearts) < 5 || south.SuitCount(Spades) > 3 {
+			t.Fatalf("South has %d hearts and %d spades", south.SuitCount(Hearts), south.SuitCount(Spades))
+		}
+		if !dc.Matches(d) {
+			t.Fatal("Matches() = false for a generated deal")
+		}
+	}
+}
+
+func TestDealGenerator_Reproducible(t *testing.T) {
+	dc, err := ParseDealConstraints("n:hcp=18-19,balanced")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := NewDealGenerator(9, dc).Next(context.Background())
+	b, _ := NewDealGenerator(9, dc).Next(context.Background())
+	if a.ID() != b.ID() {
+		t.Error("the same seed should generate the same deal")
+	}
+}
+
+func TestDealGenerator_Impossible(t *testing.T) {
+	var dc DealConstraints
+	dc[East] = &SeatConstraint{HCP: &Range{37, 37}, Spades: &Range{13, 13}}
+	g := NewDealGenerator(1, dc)
+	g.MaxAttempts = 1000
+	if _, err := g.Next(context.Background()); !errors.Is(err, ErrNoMatchingDeal) {
+		t.Errorf("Next() error = %v, want ErrNoMatchingDeal", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewDealGenerator(1, DealConstraints{}).Next(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Next() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestDealConstraints_Validate(t *testing.T) {
+	tests := []struct {
+		spec string
+		ok   bool
+	}{
+		{"", true},
+		{"n:hcp=15-17,balanced;s:hearts=5+,hcp=0-7", true},
+		{"n:hcp=37", true},
+		{"n:spades=13;s:hearts=13;e:diamonds=13;w:clubs=13", true},
+		{"n:hcp=38", false},                                // More than a hand can hold
+		{"n:hcp=22+;s:hcp=19+", false},                     // The minimums come to 41
+		{"n:hcp=0-9;e:hcp=0-9;s:hcp=0-9;w:hcp=0-9", false}, // 36 at most
+		{"n:hearts=7+;s:hearts=7+", false},                 // 14 hearts
+		{"n:spades=0;e:spades=0-4;s:spades=0-4;w:spades=0-4", false},
+		{"n:spades=7+,hearts=7+", false}, // 14 cards
+		{"n:spades=0-2,hearts=0-2,diamonds=0-2,clubs=0-2", false},
+	}
+	for _, tt := range tests {
+		dc, err := ParseDealConstraints(tt.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = dc.Validate()
+		if (err == nil) != tt.ok {
+			t.Errorf("Validate(%q) = %v, want ok %v", tt.spec, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, ErrNoMatchingDeal) {
+			t.Errorf("Validate(%q) = %v, want ErrNoMatchingDeal", tt.spec, err)
+		}
+	}
+}
+
+func TestParseDealConstraints(t *testing.T) {
+	dc, err := ParseDealConstraints("north:hcp=15-17,balanced; S:hearts=5+,clubs=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dc[North] == nil || *dc[North].HCP != (Range{15, 17}) || !*dc[North].Balanced {
+		t.Errorf("North constraint = %+v", dc[North])
+	}
+	if dc[South] == nil || *dc[South].Hearts != (Range{5, 40}) || *dc[South].Clubs != (Range{0, 0}) {
+		t.Errorf("South constraint = %+v", dc[South])
+	}
+	if dc[East] != nil || dc[West] != nil {
+		t.Error("East and West should be unconstrained")
+	}
+
+	for _, bad := range []string{"north", "up:hcp=1", "n:hcp=9-3", "n:length=5", "n:shapely"} {
+		if _, err := ParseDealConstraints(bad); err == nil {
+			t.Errorf("ParseDealConstraints(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSeatConstraint_JSON(t *testing.T) {
+	var c SeatConstraint
+	if err := json.Unmarshal([]byte(`{"hcp":[18,40],"clubs":[5,13],"balanced":false}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if *c.HCP != (Range{18, 40}) || *c.Clubs != (Range{5, 13}) || *c.Balanced {
+		t.Errorf("Unmarshal() = %+v", c)
+	}
+	if err := json.Unmarshal([]byte(`{"hcp":[12,10]}`), &c); err == nil {
+		t.Error("an empty range should be rejected")
+	}
+}
+
+func BenchmarkDealGenerator(b *testing.B) {
+	dc, _ := ParseDealConstraints("n:hcp=15-17,balanced;s:hearts=5+")
+	g := NewDealGenerator(1, dc)
+	for i := 0; i < b.N; i++ {
+		if _, err := g.Next(context.Background()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAuctionNotFinished is returned when a contract is requested from an
+// auction that is still in progress.
+var ErrAuctionNotFinished = errors.New("the auction is not finished")
+
+// Contract is the final contract reached by a finished auction.
+type Contract struct {
+	Level     int
+	Strain    Suit
+	Doubled   bool
+	Redoubled bool
+	Declarer  Position
+	PassedOut bool // True if all four players passed; other fields are unset
+}
+
+// NewContract resolves the final contract of a finished auction. The
+// declarer is the first player of the declaring side to name the strain.
+func NewContract(a *Auction) (Contract, error) {
+	if a.IsPassedOut() {
+		return Contract{PassedOut: true}, nil
+	}
+	if !a.IsAuctionComplete() {
+		return Contract{}, ErrAuctionNotFinished
+	}
+
+	idx := a.lastContractIndex()
+	last := a.Bids[idx]
+	c := Contract{
+		Level:    last.Level,
+		Strain:   last.Strain,
+		Declarer: last.Position,
+	}
+
+	// Doubles and redoubles only count if made after the final bid.
+	for _, b := range a.Bids[idx+1:] {
+		if b.Double {
+			c.Doubled = true
+		}
+		if b.Redouble {
+			c.Doubled = false
+			c.Redoubled = true
+		}
+	}
+
+	// The declarer is whoever on the declaring side bid the strain first.
+	for _, b := range a.Bids[:idx+1] {
+		if b.Pass || b.Double || b.Redouble {
+			continue
+		}
+		if b.Strain == c.Strain && (b.Position == last.Position || b.Position == last.Position.Partner()) {
+			c.Declarer = b.Position
+			break
+		}
+	}
+
+	return c, nil
+}
+
+// Dummy returns the declarer's partner.
+func (c Contract) Dummy() Position {
+	return c.Declarer.Partner()
+}
+
+// OpeningLeader returns the player on the declarer's left, who makes the opening lead.
+func (c Contract) OpeningLeader() Position {
+	return (c.Declarer + 1) % 4
+}
+
+// TricksRequired returns the number of tricks the declarer needs to make the contract.
+func (c Contract) TricksRequired() int {
+	return c.Level + 6
+}
+
+// Bid returns the contract as a Bid, with the double and redouble flags set,
+// as expected by CalculateScore.
+func (c Contract) Bid() Bid {
+	return Bid{
+		Level:    c.Level,
+		Strain:   c.Strain,
+		Position: c.Declarer,
+		Double:   c.Doubled,
+		Redouble: c.Redoubled,
+	}
+}
+
+// String returns the contract in the usual short form, e.g. "4HX by South".
+func (c Contract) String() string {
+	if c.PassedOut {
+		return "Passed out"
+	}
+	suffix := ""
+	switch {
+	case c.Redoubled:
+		suffix = "XX"
+	case c.Doubled:
+		suffix = "X"
+	}
+	return fmt.Sprintf("%s%s by %s", NewBid(c.Level, c.Strain), suffix, c.Declarer)
+}
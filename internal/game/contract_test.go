@@ -0,0 +1,129 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewContract(t *testing.T) {
+	tests := []struct {
+		name string
+		bids []Bid
+		want Contract
+	}{
+		{
+			name: "Simple contract",
+			bids: []Bid{
+				{Level: 1, Strain: NoTrump, Position: North},
+				{Pass: true, Position: East},
+				{Level: 3, Strain: NoTrump, Position: South},
+				{Pass: true, Position: West},
+				{Pass: true, Position: North},
+				{Pass: true, Position: East},
+			},
+			want: Contract{Level: 3, Strain: NoTrump, Declarer: North},
+		},
+		{
+			name: "Partner named the strain first",
+			bids: []Bid{
+				{Pass: true, Position: North},
+				{Level: 1, Strain: Hearts, Position: East},
+				{Pass: true, Position: South},
+				{Level: 1, Strain: Spades, Position: West},
+				{Pass: true, Position: North},
+				{Level: 2, Strain: Spades, Position: East},
+				{Pass: true, Position: South},
+				{Level: 4, Strain: Spades, Position: West},
+				{Pass: true, Position: North},
+				{Pass: true, Position: East},
+				{Pass: true, Position: South},
+			},
+			want: Contract{Level: 4, Strain: Spades, Declarer: West},
+		},
+		{
+			name: "Opponent's earlier bid of the strain does not count",
+			bids: []Bid{
+				{Level: 1, Strain: Hearts, Position: North},
+				{Level: 2, Strain: Hearts, Position: East},
+				{Pass: true, Position: South},
+				{Level: 4, Strain: Hearts, Position: West},
+				{Pass: true, Position: North},
+				{Pass: true, Position: East},
+				{Pass: true, Position: South},
+			},
+			want: Contract{Level: 4, Strain: Hearts, Declarer: East},
+		},
+		{
+			name: "Doubled",
+			bids: []Bid{
+				{Level: 4, Strain: Hearts, Position: North},
+				{Double: true, Position: East},
+				{Pass: true, Position: South},
+				{Pass: true, Position: West},
+				{Pass: true, Position: North},
+			},
+			want: Contract{Level: 4, Strain: Hearts, Declarer: North, Doubled: true},
+		},
+		{
+			name: "Redoubled",
+			bids: []Bid{
+				{Level: 1, Strain: Clubs, Position: East},
+				{Double: true, Position: South},
+				{Redouble: true, Position: West},
+				{Pass: true, Position: North},
+				{Pass: true, Position: East},
+				{Pass: true, Position: South},
+			},
+			want: Contract{Level: 1, Strain: Clubs, Declarer: East, Redoubled: true},
+		},
+		{
+			name: "Double cancelled by a later bid",
+			bids: []Bid{
+				{Level: 1, Strain: Spades, Position: North},
+				{Double: true, Position: East},
+				{Level: 2, Strain: Spades, Position: South},
+				{Pass: true, Position: West},
+				{Pass: true, Position: North},
+				{Pass: true, Position: East},
+			},
+			want: Contract{Level: 2, Strain: Spades, Declarer: North},
+		},
+		{
+			name: "Passed out",
+			bids: []Bid{NewPass(), NewPass(), NewPass(), NewPass()},
+			want: Contract{PassedOut: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewContract(&Auction{Bids: tt.bids})
+			if err != nil {
+				t.Fatalf("NewContract() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NewContract() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewContract_Unfinished(t *testing.T) {
+	a := &Auction{Bids: []Bid{NewBid(1, Clubs), NewPass()}}
+	if _, err := NewContract(a); !errors.Is(err, ErrAuctionNotFinished) {
+		t.Errorf("NewContract() error = %v, want %v", err, ErrAuctionNotFinished)
+	}
+}
+
+func TestContract_Seats(t *testing.T) {
+	c := Contract{Level: 4, Strain: Spades, Declarer: West, Doubled: true}
+	if c.Dummy() != East {
+		t.Errorf("Dummy() = %s, want East", c.Dummy())
+	}
+	if c.OpeningLeader() != North {
+		t.Errorf("OpeningLeader() = %s, want North", c.OpeningLeader())
+	}
+	if c.String() != "4SX by West" {
+		t.Errorf("String() = %q, want %q", c.String(), "4SX by West")
+	}
+}
@@ -0,0 +1,981 @@
+package game
+
+import (
+	"context"
+	"math/bits"
+	"sync"
+)
+
+// TrickTable holds the double-dummy trick counts for a deal: the number of
+// tricks declarer's side takes in each strain for each declarer, with best
+// play by all four hands.
+type TrickTable [5][4]int
+
+// Tricks returns the number of tricks for a contract in strain played by declarer.
+func (t TrickTable) Tricks(strain Suit, declarer Position) int {
+	return t[strain][declarer]
+}
+
+// SolveDeal computes the 20-entry double-dummy trick table for a deal.
+func SolveDeal(d Deal) TrickTable {
+	t, _, _ := solveDeal(context.Background(), d)
+	return t
+}
+
+// solveDeal solves the trick table and also returns the number of cards
+// played in the searches. No trumps goes first: its results are where the
+// searches in the suits start, and the suits, solved concurrently, read its
+// transposition table once the trumps are gone. Once ctx is done the
+// searches stop, and the context's error is returned.
+func solveDeal(ctx context.Context, d Deal) (TrickTable, int, error) {
+	var solvers [5]*ddSolver
+	for strain := range solvers {
+		solvers[strain] = newDDSolver(d.Hands, Suit(strain))
+		solvers[strain].ctx = ctx
+	}
+	// The four searches for a strain share one transposition table:
+	// positions are stored by the seat on lead, whoever declares.
+	var ns [5][4]int
+	nt := solvers[NoTrump]
+	ns[NoTrump] = nt.solveLeaders(-1)
+	if nt.err != nil {
+		return TrickTable{}, nt.nodes, nt.err
+	}
+	var wg sync.WaitGroup
+	for strain := Clubs; strain <= Spades; strain++ {
+		wg.Add(1)
+		go func(s *ddSolver) {
+			defer wg.Done()
+			s.notrumps = nt.tt
+			ns[s.trumps] = s.solveLeaders(ns[NoTrump][North])
+		}(solvers[strain])
+	}
+	wg.Wait()
+
+	var t TrickTable
+	nodes := 0
+	for _, s := range solvers {
+		nodes += s.nodes
+		if s.err != nil {
+			return TrickTable{}, nodes, s.err
+		}
+	}
+	for strain := range solvers {
+		for leader := North; leader <= West; leader++ {
+			t[strain][(leader+3)%4] = declarerTricks(ns[strain][leader], (leader+3)%4)
+		}
+	}
+	return t, nodes, nil
+}
+
+// PendingTable is a trick table being solved in the background.
+type PendingTable struct {
+	done  chan struct{}
+	table TrickTable
+	err   error
+}
+
+// NewPendingTable returns a table for Solve to fill in, for a caller that
+// runs the solve itself, such as a server bounding its background work.
+func NewPendingTable() *PendingTable {
+	return &PendingTable{done: make(chan struct{})}
+}
+
+// Solve solves a deal's trick table for those waiting on p. Once ctx is
+// done the solve stops, and Err reports the context's error. It must be
+// called exactly once.
+func (p *PendingTable) Solve(ctx context.Context, d Deal) {
+	defer close(p.done)
+	if p.err = ctx.Err(); p.err == nil {
+		p.table, _, p.err = solveDeal(ctx, d)
+	}
+}
+
+// SolveDealAsync starts solving a deal's trick table in the background, so
+// that a caller with other work to do need not wait for it. Once ctx is
+// done the solve stops, and Err reports why.
+func SolveDealAsync(ctx context.Context, d Deal) *PendingTable {
+	p := NewPendingTable()
+	go p.Solve(ctx, d)
+	return p
+}
+
+// Ready returns the table, or false while it is still being solved or if
+// the solve was stopped.
+func (p *PendingTable) Ready() (TrickTable, bool) {
+	select {
+	case <-p.done:
+		return p.table, p.err == nil
+	default:
+		return TrickTable{}, false
+	}
+}
+
+// Err returns the context's error once the solve has stopped short, and nil
+// while it runs or once the table is solved.
+func (p *PendingTable) Err() error {
+	select {
+	case <-p.done:
+		return p.err
+	default:
+		return nil
+	}
+}
+
+// Wait returns the table once it is solved, or the context's error if the
+// solve was stopped.
+func (p *PendingTable) Wait() (TrickTable, error) {
+	<-p.done
+	return p.table, p.err
+}
+
+// SolveTricks returns the number of tricks declarer takes double dummy in
+// the given strain. Once ctx is done the search stops, and the context's
+// error is returned.
+func SolveTricks(ctx context.Context, d Deal, strain Suit, declarer Position) (int, error) {
+	s := newDDSolver(d.Hands, strain)
+	s.ctx = ctx
+	ns := s.solve(int(declarer+1)%4, -1)
+	if s.err != nil {
+		return 0, s.err
+	}
+	return declarerTricks(ns, declarer), nil
+}
+
+// SolvePlay returns, for each legal card of the seat on turn, the total
+// number of tricks declarer takes if that card is played and everyone plays
+// double dummy from then on. Tricks already won count towards the total.
+// This scores opening leads when called before the first card is played.
+// Touching cards, with no card of another seat or of the trick between
+// them, are only solved once. Once ctx is done the search stops, and the
+// context's error is returned.
+func SolvePlay(ctx context.Context, p *Play) (map[Card]int, error) {
+	s := newDDSolver(p.Hands, p.Trumps())
+	s.ctx = ctx
+	leader, n := int(p.Turn), 0
+	var inTrick ddRanks
+	if t := p.CurrentTrick(); t != nil {
+		leader, n = int(t.Leader), len(t.Cards)
+		for i, c := range t.Cards {
+			s.trick[i] = ddCard{uint8(c.Suit), uint8(c.Rank)}
+			inTrick[c.Suit] |= 1 << c.Rank
+		}
+	}
+	remaining := s.cardsLeft(int(p.Turn)) // Including the trick in progress
+	nsWon := p.TricksWon(NorthSouth)
+
+	results := make(map[Card]int)
+	guess := -1
+	var last Card
+	for i, c := range p.LegalCards() {
+		// Cards with no other seat's card between them are worth the same.
+		if i > 0 && last.Suit == c.Suit && s.touching(int(p.Turn), last, c, inTrick) {
+			results[c] = results[last]
+			last = c
+			continue
+		}
+		last = c
+		m := ddCard{uint8(c.Suit), uint8(c.Rank)}
+		lengths, owners := s.lengths, s.owners
+		s.play(int(p.Turn), m)
+		s.trick[n] = m
+		ns := s.solveFrom(leader, n+1, remaining, guess)
+		s.hands[p.Turn][m.suit] |= 1 << m.rank
+		s.lengths, s.owners = lengths, owners
+		if s.err != nil {
+			return nil, s.err
+		}
+		guess = ns
+		results[c] = declarerTricks(nsWon+ns, p.Contract.Declarer)
+	}
+	return results, nil
+}
+
+// touching reports whether no other seat holds a card ranking between
+// seat's cards hi and lo of one suit, and none lies in the trick in progress.
+func (s *ddSolver) touching(seat int, hi, lo Card, trick ddRanks) bool {
+	others := s.allCards(int(hi.Suit))&^s.hands[seat][hi.Suit] | trick[hi.Suit]
+	between := uint16(1)<<hi.Rank - uint16(1)<<(lo.Rank+1)
+	return others&between == 0
+}
+
+// declarerTricks converts a number of tricks for North-South out of 13
+// into tricks for declarer's side.
+func declarerTricks(ns int, declarer Position) int {
+	if declarer.Side() == NorthSouth {
+		return ns
+	}
+	return 13 - ns
+}
+
+// ddCard is a card in the solver's compact form.
+type ddCard struct {
+	suit, rank uint8
+}
+
+// noCard marks the absence of a card.
+var noCard = ddCard{255, 0}
+
+// ddRanks is a set of ranks in each suit.
+type ddRanks [4]uint16
+
+// ddSolver searches a deal double dummy. Hands are bitmasks of ranks by
+// seat and suit. Search results are kept in a transposition table at the
+// start of each trick, for the shape of the position: the length of every
+// hand in every suit, four bits each, in lengths. A stored result applies
+// to every position of that shape in which the cards that decided it lie
+// the same way. To compare them, owners holds the seat of every remaining
+// card, two bits each, suit by suit from the highest card down, in a 32-bit
+// field per suit. Clubs and diamonds share the first word, hearts and
+// spades the second.
+type ddSolver struct {
+	hands   [4][4]uint16
+	trumps  int // Suit index, or 4 for no trumps
+	trick   [4]ddCard
+	lengths uint64
+	owners  [2]uint64
+	tt      map[uint64]ddBucket
+	nodes   int // Cards played in the search so far
+	// The no-trump table of the deal, if solved, which only reads it.
+	// Once the trumps are gone a position plays as it would in no trumps.
+	notrumps map[uint64]ddBucket
+	// The search gives up once ctx, if set, is done, leaving its error in
+	// err. Results found after that are not stored.
+	ctx context.Context
+	err error
+}
+
+// ddBucket holds the entries for one shape, oldest first, which lookups
+// read newest first. With each entry goes a summary of the owners of the
+// top four cards of every suit that it records, in the low half, and of
+// which of those cards it records, in the high half, so that most entries
+// for other positions are passed over without reading them.
+type ddBucket struct {
+	tops    []uint64
+	entries []ddEntry
+}
+
+// ddTops returns the bits for the top four cards of every suit in an owners
+// field, eight bits a suit.
+func ddTops(owners [2]uint64) uint64 {
+	return owners[0]>>24&0xff | owners[0]>>56<<8 | owners[1]>>24&0xff<<16 | owners[1]>>56<<24
+}
+
+// ddEntry records bounds on the tricks North-South take from the positions
+// of one shape whose top cards lie the same way: the owners of the top n
+// cards of each suit are given by the bits of owners under mask. Positions
+// that differ only in lower cards share the entry.
+type ddEntry struct {
+	mask, owners [2]uint64
+	lo, hi       int8
+	lead         ddCard // Lead that last reached a bound, or noCard
+}
+
+func newDDSolver(hands [4]*Hand, trumps Suit) *ddSolver {
+	s := &ddSolver{trumps: int(trumps), tt: make(map[uint64]ddBucket)}
+	for seat, h := range hands {
+		for _, c := range h.Cards {
+			s.hands[seat][c.Suit] |= 1 << c.Rank
+		}
+	}
+	for seat := 0; seat < 4; seat++ {
+		for suit := 0; suit < 4; suit++ {
+			s.lengths |= uint64(bits.OnesCount16(s.hands[seat][suit])) << (4 * uint(seat*4+suit))
+		}
+	}
+	for suit := 0; suit < 4; suit++ {
+		var owners uint32
+		for r := Ace; r >= Two; r-- {
+			for seat := 0; seat < 4; seat++ {
+				if s.hands[seat][suit]>>r&1 == 1 {
+					owners = owners<<2 | uint32(seat)
+				}
+			}
+		}
+		n := bits.OnesCount16(s.allCards(suit))
+		s.owners[suit/2] |= uint64(owners<<(32-2*uint(n))) << (32 * uint(suit%2))
+	}
+	return s
+}
+
+// allCards returns the remaining cards of a suit, in any hand.
+func (s *ddSolver) allCards(suit int) uint16 {
+	return s.hands[0][suit] | s.hands[1][suit] | s.hands[2][suit] | s.hands[3][suit]
+}
+
+// cardsLeft returns the number of cards a seat still holds.
+func (s *ddSolver) cardsLeft(seat int) int {
+	h := s.hands[seat]
+	return bits.OnesCount64(uint64(h[0]) | uint64(h[1])<<16 | uint64(h[2])<<32 | uint64(h[3])<<48)
+}
+
+// play removes a card from a seat's hand. The caller restores the hand,
+// lengths and owners afterwards.
+func (s *ddSolver) play(seat int, c ddCard) {
+	above := 2 * uint(bits.OnesCount16(s.allCards(int(c.suit))>>(c.rank+1)))
+	shift := 32 * uint(c.suit%2)
+	f := uint32(s.owners[c.suit/2] >> shift)
+	f = f&^(^uint32(0)>>above) | f<<(above+2)>>above
+	s.owners[c.suit/2] = s.owners[c.suit/2]&^(uint64(^uint32(0))<<shift) | uint64(f)<<shift
+	s.lengths -= 1 << (4 * uint(seat*4+int(c.suit)))
+	s.hands[seat][c.suit] &^= 1 << c.rank
+}
+
+// solve returns the number of tricks North-South take with leader on lead
+// to the first trick. The search is quickest when guess, if not negative,
+// is close to the answer.
+func (s *ddSolver) solve(leader, guess int) int {
+	return s.solveFrom(leader, 0, s.cardsLeft(leader), guess)
+}
+
+// solveLeaders returns the tricks North-South take with each seat on lead.
+// The search for North starts from guess. A seat's partner on lead usually
+// makes the same number of tricks, or else the seat before it, so that is
+// where the later searches start.
+func (s *ddSolver) solveLeaders(guess int) [4]int {
+	var ns [4]int
+	for leader := range ns {
+		if leader > 0 {
+			guess = ns[max(leader-2, 0)]
+		}
+		ns[leader] = s.solve(leader, guess)
+	}
+	return ns
+}
+
+// solveFrom returns the number of tricks North-South take from the current
+// position, in which n cards of the trick led by leader have been played
+// and remaining tricks, including this one, are still to be won. It runs a
+// series of zero-window searches, stepping from guess towards the answer
+// and halving the range once the guess proves to be far off.
+func (s *ddSolver) solveFrom(leader, n, remaining, guess int) int {
+	if n == 4 {
+		wi := s.winningIndex(4)
+		won := 1 - (leader+wi)%2
+		trick := s.trick // The next trick reuses the buffer
+		ns := won + s.solveFrom((leader+wi)%4, 0, remaining-1, guess-won)
+		s.trick = trick
+		return ns
+	}
+	lo, hi := 0, remaining
+	target := guess
+	for i := 0; lo < hi && s.err == nil; i++ {
+		if i >= 2 || target <= lo || target > hi {
+			target = (lo + hi + 1) / 2
+		}
+		var ok bool
+		if n == 0 {
+			ok, _ = s.search(leader, remaining, target)
+		} else {
+			ok, _, _ = s.searchTrick(leader, n, s.winningIndex(n), remaining, target, noCard)
+		}
+		if ok {
+			lo, target = target, target+1
+		} else {
+			hi, target = target-1, target-1
+		}
+	}
+	return lo
+}
+
+// search reports whether North-South can take target of the remaining
+// tricks with leader on lead to the next trick. It also returns the ranks
+// that decided the result: positions with the same shape whose cards down
+// to the lowest of these ranks lie the same way have the same result.
+func (s *ddSolver) search(leader, remaining, target int) (bool, ddRanks) {
+	if target <= 0 {
+		return true, ddRanks{}
+	}
+	if target > remaining {
+		return false, ddRanks{}
+	}
+	if remaining == 1 {
+		return s.lastTrick(leader)
+	}
+	// Top trumps held in one hand take a trick each whenever they are played.
+	if sure, w := s.sureTrumps(); target <= sure[0] {
+		return true, w[0]
+	} else if target > remaining-sure[1] {
+		return false, w[1]
+	}
+
+	// At the start of a trick every hand holds the same number of cards, so
+	// West's spade length can make way for the leader in the key.
+	key := s.lengths&^(0xf<<60) | uint64(leader)<<60
+	tops := ddTops(s.owners)
+	if s.notrumps != nil && s.allCards(s.trumps) == 0 {
+		if b, ok := s.notrumps[key]; ok {
+			for i := len(b.tops) - 1; i >= 0; i-- {
+				if (b.tops[i]^tops)&(b.tops[i]>>32) != 0 {
+					continue
+				}
+				if e := &b.entries[i]; s.covers(e) && (target <= int(e.lo) || target > int(e.hi)) {
+					return target <= int(e.lo), s.topRanks(e)
+				}
+			}
+		}
+	}
+	b := s.tt[key]
+	hint := noCard
+	for i := len(b.tops) - 1; i >= 0; i-- {
+		if (b.tops[i]^tops)&(b.tops[i]>>32) != 0 {
+			continue
+		}
+		e := &b.entries[i]
+		if !s.covers(e) {
+			continue
+		}
+		if target <= int(e.lo) || target > int(e.hi) {
+			ok := target <= int(e.lo)
+			w := s.topRanks(e)
+			if j := i + 1; j < len(b.tops) {
+				b.tops[i], b.tops[j] = b.tops[j], b.tops[i]
+				b.entries[i], b.entries[j] = b.entries[j], b.entries[i]
+			}
+			return ok, w
+		}
+		if e.lead != noCard {
+			hint = s.fromRelative(e.lead)
+		}
+	}
+
+	// The side on lead takes its quick tricks before giving up the lead.
+	if leader%2 == 0 {
+		if ok, w := s.quickTricks(leader, target); ok {
+			s.store(key, b, remaining, target, true, noCard, w)
+			return true, w
+		}
+	} else if ok, w := s.quickTricks(leader, remaining-target+1); ok {
+		s.store(key, b, remaining, target, false, noCard, w)
+		return false, w
+	}
+
+	ok, lead, w := s.searchTrick(leader, 0, 0, remaining, target, hint)
+	if s.err != nil {
+		return false, ddRanks{}
+	}
+	s.store(key, b, remaining, target, ok, lead, w)
+	return ok, w
+}
+
+// searchTrick tries each candidate card for the seat on turn, n cards into
+// the trick led by leader, of which the card at index wi is winning.
+// North-South need one card that reaches target; East-West need one that
+// stops it. It returns the card that decided the search, if there was one,
+// and the ranks that decided the result. At the lead, hint is tried first.
+func (s *ddSolver) searchTrick(leader, n, wi, remaining, target int, hint ddCard) (bool, ddCard, ddRanks) {
+	turn := (leader + n) % 4
+	nsToMove := turn%2 == 0
+
+	var buf [13]ddCard
+	moves, equal := s.moves(leader, n, wi, hint, buf[:0])
+
+	lengths, owners := s.lengths, s.owners
+	var all ddRanks
+	var irrelevant [4]uint16 // Cards known to do no better than one that failed
+	var tried ddRanks        // Cards played, or known to fail as one that was
+	for _, m := range moves {
+		tried[m.suit] |= 1 << m.rank
+		if irrelevant[m.suit]>>m.rank&1 == 1 {
+			continue
+		}
+		if s.stopped() {
+			return false, noCard, ddRanks{}
+		}
+		s.nodes++
+		s.play(turn, m)
+		s.trick[n] = m
+		win := wi
+		if n == 0 || s.beats(m, s.trick[wi]) {
+			win = n
+		}
+
+		var ok bool
+		var w ddRanks
+		if n < 3 {
+			ok, _, w = s.searchTrick(leader, n+1, win, remaining, target, noCard)
+		} else {
+			winner := (leader + win) % 4
+			trick := s.trick // The next trick reuses the buffer
+			ok, w = s.search(winner, remaining-1, target-1+winner%2)
+			s.trick = trick
+			s.addTrickRank(&w, win)
+		}
+
+		s.hands[turn][m.suit] |= 1 << m.rank
+		s.lengths, s.owners = lengths, owners
+		if s.err != nil {
+			return false, noCard, ddRanks{}
+		}
+		if ok == nsToMove {
+			return ok, m, w
+		}
+		for i := range all {
+			all[i] |= w[i]
+		}
+		// A lower card of the suit than every rank that decided the
+		// result leaves the same cards deciding it, so it fails too.
+		if low := uint16(1) << bits.TrailingZeros16(w[m.suit]|1<<15); low > 1<<m.rank {
+			irrelevant[m.suit] |= low - 1
+		}
+	}
+	// A card passed over as equal to a higher one need not be equal to it
+	// in the other positions the result covers, where only the cards down
+	// to the lowest deciding rank lie the same way. Unless a card below
+	// that rank was tried, the result must reach down to the end of the run.
+	for suit := range all {
+		below := uint16(1)<<bits.TrailingZeros16(all[suit]|1<<15) - 1
+		if equal[suit]&below != 0 && tried[suit]&below == 0 {
+			all[suit] |= equal[suit] & -equal[suit]
+		}
+	}
+	return !nsToMove, noCard, all
+}
+
+// stopped reports whether the search has to give up. The context is read
+// once every few thousand cards played.
+func (s *ddSolver) stopped() bool {
+	if s.ctx != nil && s.err == nil && s.nodes&0xfff == 0 {
+		s.err = s.ctx.Err()
+	}
+	return s.err != nil
+}
+
+// store records the result of a search in the transposition table, along
+// with the lead that decided it.
+func (s *ddSolver) store(key uint64, b ddBucket, remaining, target int, ok bool, lead ddCard, w ddRanks) {
+	e := ddEntry{hi: int8(remaining), lead: noCard}
+	for suit := 0; suit < 4; suit++ {
+		if w[suit] != 0 {
+			top := bits.OnesCount16(s.allCards(suit) >> bits.TrailingZeros16(w[suit]))
+			e.mask[suit/2] |= uint64(^(^uint32(0) >> (2 * uint(top)))) << (32 * uint(suit%2))
+		}
+	}
+	e.owners[0] = s.owners[0] & e.mask[0]
+	e.owners[1] = s.owners[1] & e.mask[1]
+	if lead != noCard {
+		e.lead = s.toRelative(lead)
+	}
+
+	tops := ddTops(e.mask)<<32 | ddTops(e.owners)
+	for i, t := range b.tops {
+		x := &b.entries[i]
+		if t == tops && x.mask == e.mask && x.owners == e.owners {
+			if ok {
+				x.lo = max(x.lo, int8(target))
+			} else {
+				x.hi = min(x.hi, int8(target-1))
+			}
+			if e.lead != noCard {
+				x.lead = e.lead
+			}
+			return
+		}
+	}
+	if ok {
+		e.lo = int8(target)
+	} else {
+		e.hi = int8(target - 1)
+	}
+	b.tops = append(b.tops, tops)
+	b.entries = append(b.entries, e)
+	s.tt[key] = b
+}
+
+// covers reports whether an entry applies to the current position.
+func (s *ddSolver) covers(e *ddEntry) bool {
+	return (s.owners[0]^e.owners[0])&e.mask[0] == 0 && (s.owners[1]^e.owners[1])&e.mask[1] == 0
+}
+
+// topRanks returns the cards of the position that an entry records.
+func (s *ddSolver) topRanks(e *ddEntry) ddRanks {
+	var w ddRanks
+	for suit := 0; suit < 4; suit++ {
+		top := bits.OnesCount32(uint32(e.mask[suit/2]>>(32*uint(suit%2)))) / 2
+		all := s.allCards(suit)
+		for ; top > 0; top-- {
+			r := 15 - bits.LeadingZeros16(all)
+			all &^= 1 << r
+			w[suit] |= 1 << r
+		}
+	}
+	return w
+}
+
+// toRelative turns a card into the number of cards of its suit above it,
+// which identifies it across the positions an entry covers.
+func (s *ddSolver) toRelative(c ddCard) ddCard {
+	return ddCard{c.suit, uint8(bits.OnesCount16(s.allCards(int(c.suit)) >> (c.rank + 1)))}
+}
+
+// fromRelative turns a card from toRelative back into the card of the
+// current position, or noCard if there is none.
+func (s *ddSolver) fromRelative(c ddCard) ddCard {
+	all := s.allCards(int(c.suit))
+	for i := uint8(0); i < c.rank && all != 0; i++ {
+		all &^= 1 << (15 - bits.LeadingZeros16(all))
+	}
+	if all == 0 {
+		return noCard
+	}
+	return ddCard{c.suit, uint8(15 - bits.LeadingZeros16(all))}
+}
+
+// winningIndex returns the index of the card winning the first n cards of the trick.
+func (s *ddSolver) winningIndex(n int) int {
+	best := 0
+	for i := 1; i < n; i++ {
+		if s.beats(s.trick[i], s.trick[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// beats reports whether card c beats w, the card winning the trick so far.
+func (s *ddSolver) beats(c, w ddCard) bool {
+	return (c.suit == w.suit && c.rank > w.rank) || (int(c.suit) == s.trumps && int(w.suit) != s.trumps)
+}
+
+// addTrickRank adds the winning card of a complete trick to w when its rank
+// mattered, that is when another card of the same suit was played to it.
+func (s *ddSolver) addTrickRank(w *ddRanks, wi int) {
+	c := s.trick[wi]
+	for i := 0; i < 4; i++ {
+		if i != wi && s.trick[i].suit == c.suit {
+			w[c.suit] |= 1 << c.rank
+			return
+		}
+	}
+}
+
+// lastTrick reports whether North-South win the last trick, and the rank
+// that decided it.
+func (s *ddSolver) lastTrick(leader int) (bool, ddRanks) {
+	trick := s.trick
+	for i := range s.trick {
+		hand := s.hands[(leader+i)%4]
+		for suit := 0; suit < 4; suit++ {
+			if hand[suit] != 0 {
+				s.trick[i] = ddCard{uint8(suit), uint8(15 - bits.LeadingZeros16(hand[suit]))}
+			}
+		}
+	}
+	var w ddRanks
+	wi := s.winningIndex(4)
+	s.addTrickRank(&w, wi)
+	s.trick = trick
+	return (leader+wi)%2 == 0, w
+}
+
+// sureTrumps returns the tricks each side is sure to take with its trumps.
+// A trump above every trump the opponents hold wins any trick it is played
+// to, and each card of one hand goes to a different trick, so a side takes
+// at least as many tricks as either of its hands holds such trumps. Index 0
+// is North-South. The ranks that decide a side's count are the trumps from
+// the top down to the last one counted, unless the opponents have none.
+func (s *ddSolver) sureTrumps() ([2]int, [2]ddRanks) {
+	var sure [2]int
+	var w [2]ddRanks
+	if s.trumps == 4 {
+		return sure, w
+	}
+	all := s.allCards(s.trumps)
+	for side := 0; side < 2; side++ {
+		theirs := s.hands[side+1][s.trumps] | s.hands[(side+3)%4][s.trumps]
+		if theirs == 0 {
+			sure[side] = max(bits.OnesCount16(s.hands[side][s.trumps]), bits.OnesCount16(s.hands[side+2][s.trumps]))
+			continue
+		}
+		above := all &^ (uint16(1)<<(16-bits.LeadingZeros16(theirs)) - 1)
+		for _, seat := range [2]int{side, side + 2} {
+			mine := s.hands[seat][s.trumps] & above
+			if n := bits.OnesCount16(mine); n > sure[side] {
+				sure[side] = n
+				w[side][s.trumps] = above &^ (uint16(1)<<bits.TrailingZeros16(mine) - 1)
+			}
+		}
+	}
+	return sure, w
+}
+
+// quickTricks reports whether the side on lead can cash need tricks at
+// once, and the ranks that decide it. The top cards of each suit held in
+// one hand win, in a trump contract only for as many rounds as an opponent
+// who could ruff still follows. The leader cashes its own, then may lead a
+// low card to partner's top card and let partner cash, as long as partner
+// can spare the cards it throws away while the leader cashes. Only the
+// cards counted are returned, so that the result covers as many positions
+// as it can.
+func (s *ddSolver) quickTricks(leader, need int) (bool, ddRanks) {
+	own, w, cashed := s.cashable(leader, leader, need)
+	if own >= need {
+		return true, w
+	}
+	partner := (leader + 2) % 4
+	for suit := 0; suit < 4; suit++ {
+		top := 15 - bits.LeadingZeros16(s.allCards(suit))
+		if s.hands[leader][suit] == 0 || s.hands[partner][suit]>>top&1 == 0 || s.ruffable(leader, suit) {
+			continue
+		}
+		n, pw, _ := s.cashable(partner, leader, need-own)
+		discards := 0
+		for i, c := range cashed {
+			discards += max(c-bits.OnesCount16(s.hands[partner][i]), 0)
+		}
+		if discards > s.cardsLeft(partner)-n {
+			// Partner would have to throw winners away.
+			own, w = 0, ddRanks{}
+		}
+		for i := range w {
+			w[i] |= pw[i]
+		}
+		w[suit] |= 1 << top
+		return own+n >= need, w
+	}
+	return false, ddRanks{}
+}
+
+// ruffable reports whether an opponent of seat can ruff a lead of suit.
+func (s *ddSolver) ruffable(seat, suit int) bool {
+	if s.trumps == 4 || suit == s.trumps {
+		return false
+	}
+	for _, opp := range [2]int{(seat + 1) % 4, (seat + 3) % 4} {
+		if s.hands[opp][suit] == 0 && s.hands[opp][s.trumps] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// cashable returns how many of need tricks seat can cash with the top cards
+// of its own hand, the ranks it counts and the tricks it takes in each
+// suit; see quickTricks.
+func (s *ddSolver) cashable(seat, leader, need int) (int, ddRanks, [4]int) {
+	var w ddRanks
+	var cashed [4]int
+	got := 0
+	for suit := 0; suit < 4 && got < need; suit++ {
+		h := s.hands[seat][suit]
+		if h == 0 {
+			continue
+		}
+		k := 13
+		if s.trumps < 4 && suit != s.trumps {
+			for _, opp := range [2]int{(leader + 1) % 4, (leader + 3) % 4} {
+				if s.hands[opp][s.trumps] != 0 {
+					k = min(k, bits.OnesCount16(s.hands[opp][suit]))
+				}
+			}
+		}
+		if s.allCards(suit) == h {
+			// Nobody else holds the suit, so every card of it wins.
+			cashed[suit] = min(k, bits.OnesCount16(h), need-got)
+			got += cashed[suit]
+			continue
+		}
+		for all := s.allCards(suit); k > 0 && got < need && all != 0; k-- {
+			r := 15 - bits.LeadingZeros16(all)
+			if h>>r&1 == 0 {
+				break
+			}
+			all &^= 1 << r
+			w[suit] |= 1 << r
+			cashed[suit]++
+			got++
+		}
+	}
+	return got, w, cashed
+}
+
+// moves lists the candidate cards for the seat on turn, n cards into the
+// trick led by leader, of which the card at index wi is winning. They come
+// best first. Only one card is listed from each run of equivalent cards:
+// those with no other remaining card, or card played to this trick, between
+// them. The cards left out are returned as well.
+func (s *ddSolver) moves(leader, n, wi int, hint ddCard, out []ddCard) ([]ddCard, ddRanks) {
+	turn := (leader + n) % 4
+	hand := s.hands[turn]
+	if n > 0 && hand[s.trick[0].suit] != 0 {
+		return s.follow(turn, n, wi, hand[s.trick[0].suit], out)
+	}
+
+	var o ddOrder
+	if n == 0 {
+		o = ddOrder{lead: true, turn: turn}
+	} else {
+		o = ddOrder{turn: turn, winning: s.trick[wi], partnerWinning: wi%2 == n%2}
+	}
+
+	var played ddRanks
+	for i := 0; i < n; i++ {
+		played[s.trick[i].suit] |= 1 << s.trick[i].rank
+	}
+	var scores [13]int
+	var equal ddRanks
+	for suit := 0; suit < 4; suit++ {
+		h := hand[suit]
+		if h == 0 {
+			continue
+		}
+		present := s.allCards(suit) | played[suit]
+		o.setSuit(s, suit, present)
+		prev := 16
+		for h != 0 {
+			r := 15 - bits.LeadingZeros16(h)
+			h &^= 1 << r
+			if prev < 16 && present&(uint16(1)<<prev-uint16(1)<<(r+1)) == 0 {
+				prev = r // Equivalent to the card above it
+				equal[suit] |= 1 << r
+				continue
+			}
+			prev = r
+			m := ddCard{uint8(suit), uint8(r)}
+			score := o.score(s, m)
+			if m == hint {
+				score = 1000
+			}
+			// Insert in order of decreasing score.
+			i := len(out)
+			out = append(out, m)
+			for i > 0 && scores[i-1] < score {
+				out[i], scores[i] = out[i-1], scores[i-1]
+				i--
+			}
+			out[i], scores[i] = m, score
+		}
+	}
+	return out, equal
+}
+
+// follow lists the candidate cards of a seat that follows suit with the
+// cards h, n cards into the trick, of which the card at index wi is winning.
+// It lists and leaves out cards as moves does. The cards come in groups,
+// each upwards from its lowest card: in third seat, the cards that beat
+// whatever the last seat holds; the cards that beat the trick so far,
+// unless partner is winning it or is sure to win it from fourth seat; and
+// then the rest.
+func (s *ddSolver) follow(turn, n, wi int, h uint16, out []ddCard) ([]ddCard, ddRanks) {
+	led := s.trick[0].suit
+	present := s.allCards(int(led))
+	for i := 0; i < n; i++ {
+		if s.trick[i].suit == led {
+			present |= 1 << s.trick[i].rank
+		}
+	}
+	var cards uint16
+	var equal ddRanks
+	prev := 16
+	for x := h; x != 0; {
+		r := 15 - bits.LeadingZeros16(x)
+		x &^= 1 << r
+		if prev < 16 && present&(uint16(1)<<prev-uint16(1)<<(r+1)) == 0 {
+			equal[led] |= 1 << r // Equivalent to the card above it
+		} else {
+			cards |= 1 << r
+		}
+		prev = r
+	}
+
+	var groups [3]uint16
+	if w := s.trick[wi]; w.suit == led {
+		beat := cards &^ (uint16(2)<<w.rank - 1)
+		next := 15 - bits.LeadingZeros16(s.hands[(turn+1)%4][led])
+		switch {
+		case n == 1 && 15-bits.LeadingZeros16(s.hands[(turn+2)%4][led]) > max(next, int(w.rank)):
+			// Partner wins it from fourth seat: play low.
+		case n == 2 && next > int(w.rank):
+			groups[0] = cards &^ (uint16(2)<<next - 1)
+			groups[1] = beat &^ groups[0]
+		case wi%2 != n%2:
+			groups[1] = beat
+		}
+	}
+	groups[2] = cards &^ groups[0] &^ groups[1]
+	for _, set := range groups {
+		for ; set != 0; set &= set - 1 {
+			out = append(out, ddCard{led, uint8(bits.TrailingZeros16(set))})
+		}
+	}
+	return out, equal
+}
+
+// ddOrder holds what the move ordering needs to know about the trick so
+// far, worked out once per node. Cards that follow suit are ordered by
+// follow instead.
+type ddOrder struct {
+	lead           bool
+	turn           int
+	winning        ddCard // Card winning the trick so far
+	partnerWinning bool
+
+	// Worked out for each suit in turn.
+	top           int  // Highest rank of the suit left
+	length        int  // Cards of the suit the seat on turn holds
+	ruffable      bool // An opponent can ruff a lead of the suit
+	partnerTop    bool // Partner holds the top card of the suit
+	partnerSecond bool // Partner holds the second card, LHO the top one
+	ours, theirs  int  // Cards of the suit in the longer hand of each side
+}
+
+// setSuit works out what the ordering needs to know about a suit, given
+// the cards of it still held or in the trick.
+func (o *ddOrder) setSuit(s *ddSolver, suit int, present uint16) {
+	o.length = bits.OnesCount16(s.hands[o.turn][suit])
+	if !o.lead {
+		return
+	}
+	lho, partner, rho := s.hands[(o.turn+1)%4][suit], s.hands[(o.turn+2)%4][suit], s.hands[(o.turn+3)%4][suit]
+	o.top = 15 - bits.LeadingZeros16(present)
+	second := 15 - bits.LeadingZeros16(present&^(1<<o.top))
+	o.ruffable = s.ruffable(o.turn, suit)
+	o.partnerTop = partner>>o.top&1 == 1
+	o.partnerSecond = second >= 0 && partner>>second&1 == 1 && lho>>o.top&1 == 1
+	o.ours = o.length + bits.OnesCount16(partner)
+	o.theirs = max(bits.OnesCount16(lho), bits.OnesCount16(rho))
+}
+
+// score estimates how promising a card is, so that the moves most likely
+// to cause a cut-off are tried first.
+func (o *ddOrder) score(s *ddSolver, m ddCard) int {
+	suit, rank := int(m.suit), int(m.rank)
+	isTrump := suit == s.trumps
+
+	if o.lead {
+		switch {
+		case rank == o.top:
+			// Cash a winner, unless an opponent can ruff it.
+			if o.ruffable {
+				return -rank
+			}
+			return 40 + o.ours - o.theirs
+		case o.partnerTop:
+			// Lead low towards partner's winner.
+			return 30 - rank
+		case o.ruffable:
+			return -20 - rank // Into a ruff
+		case o.partnerSecond:
+			// Lead low through the top card.
+			return 45 + o.ours - o.theirs - rank
+		}
+		// Set up the suits that are longer on our side.
+		return o.ours - o.theirs + rank
+	}
+
+	// The seat is void in the suit led.
+	beats := s.beats(m, o.winning)
+	switch {
+	case o.partnerWinning:
+		if beats {
+			return -100 - rank // Ruffing partner's winner
+		}
+		return -rank
+	case beats:
+		return 60 - rank // The cheapest ruff that wins
+	case isTrump:
+		return -50 - rank
+	}
+	// Discard from length, keeping the suits with winners.
+	return o.length - rank
+}
@@ -0,0 +1,159 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+)
+
+// DealIDLength is the number of digits in a deal ID. There are about
+// 5.36e28 different deals, so every deal fits in 29 decimal digits.
+const DealIDLength = 29
+
+// ErrInvalidDealID is returned when a deal ID cannot be decoded.
+var ErrInvalidDealID = errors.New("invalid deal ID")
+
+// Deal is a complete layout of the 52 cards, one hand per position.
+type Deal struct {
+	Hands [4]*Hand // Indexed by Position
+}
+
+// NewRandomDeal shuffles a deck with the given source of randomness and
+// deals it round-robin, starting with North.
+func NewRandomDeal(r *rand.Rand) Deal {
+	deck := NewDeck()
+	deck.ShuffleWith(r)
+	return dealDeck(deck)
+}
+
+// NewShuffledDeal shuffles a fresh deck and deals it.
+func NewShuffledDeal() Deal {
+	deck := NewDeck()
+	deck.Shuffle()
+	return dealDeck(deck)
+}
+
+// DealFromSeed returns the deal produced by a seeded shuffle. The same
+// seed always gives the same deal.
+func DealFromSeed(seed int64) Deal {
+	return NewRandomDeal(rand.New(rand.NewSource(seed)))
+}
+
+// dealDeck deals the 52 cards of a deck round-robin, starting with North.
+func dealDeck(deck Deck) Deal {
+	var cards [4][]Card
+	for i, c := range deck {
+		cards[i%4] = append(cards[i%4], c)
+	}
+	var d Deal
+	for p := range d.Hands {
+		d.Hands[p] = NewHand(cards[p])
+	}
+	return d
+}
+
+// NewPlayers creates the four players and gives each their hand from the deal.
+func NewPlayers(d Deal) []*Player {
+	players := make([]*Player, 4)
+	for i := range players {
+		players[i] = NewPlayer(Position(i))
+		players[i].Deal(d.Hands[i].Cards)
+	}
+	return players
+}
+
+// DealFromPlayers collects the players' hands into a Deal.
+func DealFromPlayers(players []*Player) Deal {
+	var d Deal
+	for _, p := range players {
+		d.Hands[p.Position] = NewHand(p.Hand.Cards)
+	}
+	return d
+}
+
+// idOrder lists the cards in the order used to number deals: spades from
+// the ace down, then hearts, diamonds and clubs.
+func idOrder() []Card {
+	cards := make([]Card, 0, 52)
+	for s := Spades; s >= Clubs; s-- {
+		for r := Ace; r >= Two; r-- {
+			cards = append(cards, Card{Suit: s, Rank: r})
+		}
+	}
+	return cards
+}
+
+// countLayouts returns the number of ways to give the remaining cards to
+// the players, given how many cards each still needs.
+func countLayouts(need [4]int) *big.Int {
+	total := need[0] + need[1] + need[2] + need[3]
+	n := new(big.Int).MulRange(1, int64(total))
+	for _, k := range need {
+		n.Quo(n, new(big.Int).MulRange(1, int64(k)))
+	}
+	return n
+}
+
+// ID returns the deal's unique 29-digit deal number: its index among all
+// possible deals when they are listed in order, card by card. DealFromID
+// turns the number back into the same four hands.
+func (d Deal) ID() string {
+	owner := make(map[Card]Position, 52)
+	for p, h := range d.Hands {
+		for _, c := range h.Cards {
+			owner[c] = Position(p)
+		}
+	}
+
+	need := [4]int{13, 13, 13, 13}
+	id := new(big.Int)
+	for _, c := range idOrder() {
+		holder := owner[c]
+		// Count every layout in which this card goes to an earlier seat.
+		for p := North; p < holder; p++ {
+			if need[p] == 0 {
+				continue
+			}
+			need[p]--
+			id.Add(id, countLayouts(need))
+			need[p]++
+		}
+		need[holder]--
+	}
+	return fmt.Sprintf("%0*s", DealIDLength, id.String())
+}
+
+// DealFromID rebuilds the deal with the given deal number.
+func DealFromID(id string) (Deal, error) {
+	id = strings.TrimSpace(id)
+	n, ok := new(big.Int).SetString(id, 10)
+	if !ok || n.Sign() < 0 || n.Cmp(countLayouts([4]int{13, 13, 13, 13})) >= 0 {
+		return Deal{}, fmt.Errorf("%w: %q", ErrInvalidDealID, id)
+	}
+
+	need := [4]int{13, 13, 13, 13}
+	var cards [4][]Card
+	for _, c := range idOrder() {
+		for p := North; p <= West; p++ {
+			if need[p] == 0 {
+				continue
+			}
+			need[p]--
+			layouts := countLayouts(need)
+			if n.Cmp(layouts) < 0 {
+				cards[p] = append(cards[p], c)
+				break
+			}
+			n.Sub(n, layouts)
+			need[p]++
+		}
+	}
+
+	var d Deal
+	for p := range d.Hands {
+		d.Hands[p] = NewHand(cards[p])
+	}
+	return d, nil
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDealFromSeed(t *testing.T) {
+	d1 := DealFromSeed(42)
+	d2 := DealFromSeed(42)
+	if !reflect.DeepEqual(d1, d2) {
+		t.Error("the same seed should give the same deal")
+	}
+	if reflect.DeepEqual(d1, DealFromSeed(43)) {
+		t.Error("different seeds should give different deals")
+	}
+
+	seen := make(map[Card]bool)
+	for p, h := range d1.Hands {
+		if len(h.Cards) != 13 {
+			t.Errorf("%s has %d cards, want 13", Position(p), len(h.Cards))
+		}
+		for _, c := range h.Cards {
+			if seen[c] {
+				t.Errorf("card %s dealt twice", c)
+			}
+			seen[c] = true
+		}
+	}
+}
+
+func TestDealID_RoundTrip(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		d := DealFromSeed(seed)
+		id := d.ID()
+		if len(id) != DealIDLength {
+			t.Fatalf("ID() = %q, want %d digits", id, DealIDLength)
+		}
+		got, err := DealFromID(id)
+		if err != nil {
+			t.Fatalf("DealFromID(%q) error = %v", id, err)
+		}
+		if !reflect.DeepEqual(got, d) {
+			t.Fatalf("DealFromID(%q) did not rebuild the deal from seed %d", id, seed)
+		}
+	}
+}
+
+func TestDealID_Extremes(t *testing.T) {
+	// Deal number zero gives North the first 13 cards in ID order: all the spades.
+	first, err := DealFromID("0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Hands[North].SuitCount(Spades) != 13 || first.Hands[West].SuitCount(Clubs) != 13 {
+		t.Errorf("deal 0 should give North all spades and West all clubs")
+	}
+	if first.ID() != "00000000000000000000000000000" {
+		t.Errorf("ID() = %q, want all zeros", first.ID())
+	}
+
+	// The highest deal number reverses the order.
+	last, err := DealFromID("53644737765488792839237439999")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Hands[West].SuitCount(Spades) != 13 || last.Hands[North].SuitCount(Clubs) != 13 {
+		t.Errorf("the last deal should give West all spades and North all clubs")
+	}
+}
+
+func TestDealFromID_Invalid(t *testing.T) {
+	for _, id := range []string{"", "abc", "-1", "53644737765488792839237440000"} {
+		if _, err := DealFromID(id); !errors.Is(err, ErrInvalidDealID) {
+			t.Errorf("DealFromID(%q) error = %v, want ErrInvalidDealID", id, err)
+		}
+	}
+}
+
+func TestNewPlayers(t *testing.T) {
+	d := DealFromSeed(7)
+	players := NewPlayers(d)
+	if got := DealFromPlayers(players); got.ID() != d.ID() {
+		t.Errorf("DealFromPlayers() ID = %s, want %s", got.ID(), d.ID())
+	}
+}
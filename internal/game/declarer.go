@@ -0,0 +1,376 @@
+package game
+
+import (
+	"math/bits"
+	"math/rand"
+)
+
+// DeclarerAI plays the cards of declarer and dummy. Like a human declarer
+// it sees only those two hands and the cards played so far. It plans the
+// play with a few simple techniques: ruffing losers in the hand with fewer
+// trumps, drawing trumps, finessing against a missing honour and setting up
+// long suits. Choices between equally good suits come from a seeded source,
+// so a given seed always gives the same play.
+type DeclarerAI struct {
+	rng *rand.Rand
+}
+
+// NewDeclarerAI returns a declarer whose choices are fixed by seed.
+func NewDeclarerAI(seed int64) *DeclarerAI {
+	return &DeclarerAI{rng: rand.New(rand.NewSource(seed))}
+}
+
+// ChooseCard picks a card for the seat on turn. Declarer's and dummy's
+// cards follow the plan; the defenders' cards fall back to ChooseCard. The
+// play must not be over.
+func (ai *DeclarerAI) ChooseCard(p *Play) Card {
+	if p.Controller(p.Turn) != p.Contract.Declarer {
+		return ChooseCard(p)
+	}
+	v := newDeclarerView(p)
+	if p.CurrentTrick() == nil {
+		return ai.lead(v)
+	}
+	return v.follow()
+}
+
+// allRanks has a bit set for every rank from Two to Ace.
+const allRanks uint16 = 0x7ffc
+
+// declarerView is what declarer knows when choosing a card for me, the
+// seat on turn: the cards me and partner hold, and the cards the defenders
+// still hold between them, by suit as bitmasks of ranks.
+type declarerView struct {
+	p       *Play
+	trumps  Suit
+	me, pd  Position
+	mine    [4]uint16
+	partner [4]uint16
+	unseen  [4]uint16
+}
+
+func newDeclarerView(p *Play) *declarerView {
+	v := &declarerView{p: p, trumps: p.Trumps(), me: p.Turn, pd: p.Turn.Partner()}
+	for _, c := range p.Hands[v.me].Cards {
+		v.mine[c.Suit] |= 1 << c.Rank
+	}
+	for _, c := range p.Hands[v.pd].Cards {
+		v.partner[c.Suit] |= 1 << c.Rank
+	}
+	var played [4]uint16
+	for _, c := range p.Cards() {
+		played[c.Suit] |= 1 << c.Rank
+	}
+	for s := range v.unseen {
+		v.unseen[s] = allRanks &^ v.mine[s] &^ v.partner[s] &^ played[s]
+	}
+	return v
+}
+
+// above returns the number of cards the defenders hold above c.
+func (v *declarerView) above(c Card) int {
+	return bits.OnesCount16(v.unseen[c.Suit] >> (c.Rank + 1))
+}
+
+// isMaster reports whether no defender can beat c in its suit.
+func (v *declarerView) isMaster(c Card) bool {
+	return v.above(c) == 0
+}
+
+// count returns the number of cards in a holding.
+func count(holding uint16) int {
+	return bits.OnesCount16(holding)
+}
+
+// top and bottom return the highest and lowest card of a non-empty holding.
+func top(s Suit, holding uint16) Card {
+	return Card{s, Rank(15 - bits.LeadingZeros16(holding))}
+}
+
+func bottom(s Suit, holding uint16) Card {
+	return Card{s, Rank(bits.TrailingZeros16(holding))}
+}
+
+// lead chooses the card for me to lead to the next trick.
+func (ai *DeclarerAI) lead(v *declarerView) Card {
+	if v.trumps != NoTrump {
+		if c, ok := v.ruff(); ok {
+			return c
+		}
+		if c, ok := v.drawTrumps(); ok {
+			return c
+		}
+	}
+	if c, ok := v.finesse(); ok {
+		return c
+	}
+	if c, ok := ai.establish(v); ok {
+		return c
+	}
+	if c, ok := v.cash(); ok {
+		return c
+	}
+	return ai.giveUp(v)
+}
+
+// ruff leads a losing card towards partner to ruff when partner holds
+// fewer trumps than me and is void in the suit. Ruffs in the long trump
+// hand win no extra tricks.
+func (v *declarerView) ruff() (Card, bool) {
+	t := v.trumps
+	if v.partner[t] == 0 || count(v.partner[t]) >= count(v.mine[t]) {
+		return Card{}, false
+	}
+	best, losers := Card{}, 0
+	for s := Clubs; s <= Spades; s++ {
+		if s == t || v.partner[s] != 0 || v.mine[s] == 0 {
+			continue
+		}
+		n := 0
+		for h := v.mine[s]; h != 0; h &^= 1 << top(s, h).Rank {
+			if !v.isMaster(top(s, h)) {
+				n++
+			}
+		}
+		if n > losers {
+			best, losers = bottom(s, v.mine[s]), n
+		}
+	}
+	return best, losers > 0
+}
+
+// drawTrumps leads a trump while the defenders may still hold one and we
+// have more trumps than they do. A master trump left with the defenders is
+// not worth a round: they take it whenever they choose.
+func (v *declarerView) drawTrumps() (Card, bool) {
+	t := v.trumps
+	out := v.unseen[t]
+	if out == 0 || v.mine[t] == 0 || count(v.mine[t])+count(v.partner[t]) <= count(out) {
+		return Card{}, false
+	}
+	if count(out) == 1 && top(t, out).Rank > top(t, v.mine[t]|v.partner[t]).Rank {
+		return Card{}, false
+	}
+	if high := top(t, v.mine[t]); v.isMaster(high) {
+		return high, true
+	}
+	return v.knockOut(t), true
+}
+
+// knockOut leads in a suit where the defenders hold the top card: the top
+// of a sequence of honours to drive it out, otherwise a low card towards
+// partner's honours.
+func (v *declarerView) knockOut(s Suit) Card {
+	high := top(s, v.mine[s])
+	if high.Rank >= Ten && v.mine[s]>>(high.Rank-1)&1 == 1 {
+		return high
+	}
+	return bottom(s, v.mine[s])
+}
+
+// finesse leads low towards a card of partner's that only one missing
+// honour beats, such as the queen of A-Q or the king of K-x. Playing it
+// when the next defender plays low wins whenever that defender holds the
+// honour.
+func (v *declarerView) finesse() (Card, bool) {
+	for s := Clubs; s <= Spades; s++ {
+		if v.mine[s] == 0 || (s == v.trumps && v.unseen[s] == 0) {
+			continue
+		}
+		if f, ok := v.finesseCard(s, v.partner[s]); ok && bottom(s, v.mine[s]).Rank < f.Rank && top(s, v.mine[s]).Rank < f.Rank {
+			return bottom(s, v.mine[s]), true
+		}
+	}
+	return Card{}, false
+}
+
+// finesseCard returns the card of a holding to finesse with, if any: a
+// card with exactly one missing card above it, backed by a higher card or
+// a spare low card so that the holding survives the honour being played
+// first.
+func (v *declarerView) finesseCard(s Suit, holding uint16) (Card, bool) {
+	for h := holding; h != 0; h &^= 1 << top(s, h).Rank {
+		c := top(s, h)
+		switch n := v.above(c); {
+		case n == 0:
+			continue
+		case n > 1 || c.Rank < Ten:
+			return Card{}, false
+		case holding>>(c.Rank+1) != 0 || count(holding) >= 2:
+			return c, true
+		}
+		return Card{}, false
+	}
+	return Card{}, false
+}
+
+// establish works on the longest suit in which we hold more cards than the
+// defenders but do not yet control every trick: cashing winners from the
+// shorter hand first, then giving up the tricks the defenders must take.
+func (ai *DeclarerAI) establish(v *declarerView) (Card, bool) {
+	var candidates []Suit
+	longest := 0
+	for s := Clubs; s <= Spades; s++ {
+		ours := count(v.mine[s]) + count(v.partner[s])
+		if s == v.trumps || v.mine[s] == 0 || v.unseen[s] == 0 || ours <= count(v.unseen[s]) {
+			continue
+		}
+		switch {
+		case ours > longest:
+			candidates, longest = []Suit{s}, ours
+		case ours == longest:
+			candidates = append(candidates, s)
+		}
+	}
+	if len(candidates) == 0 {
+		return Card{}, false
+	}
+	s := candidates[ai.rng.Intn(len(candidates))]
+
+	mineShort := count(v.mine[s]) <= count(v.partner[s])
+	switch {
+	case v.isMaster(top(s, v.mine[s])) && (mineShort || v.partner[s] == 0 || !v.isMaster(top(s, v.partner[s]))):
+		return top(s, v.mine[s]), true
+	case v.partner[s] != 0 && v.isMaster(top(s, v.partner[s])):
+		return bottom(s, v.mine[s]), true // Partner wins and continues the suit
+	}
+	return v.knockOut(s), true
+}
+
+// cash leads a winner, from the suit in which I am shorter than partner
+// first so as not to block the suit.
+func (v *declarerView) cash() (Card, bool) {
+	var best Card
+	found, bestShort := false, false
+	for s := Clubs; s <= Spades; s++ {
+		if v.mine[s] == 0 || !v.isMaster(top(s, v.mine[s])) {
+			continue
+		}
+		short := count(v.mine[s]) <= count(v.partner[s])
+		if !found || (short && !bestShort) {
+			best, found, bestShort = top(s, v.mine[s]), true, short
+		}
+	}
+	return best, found
+}
+
+// giveUp leads low from my longest side suit, or a trump when that is all
+// I hold.
+func (ai *DeclarerAI) giveUp(v *declarerView) Card {
+	var candidates []Suit
+	longest := 0
+	for s := Clubs; s <= Spades; s++ {
+		n := count(v.mine[s])
+		if n == 0 || s == v.trumps {
+			continue
+		}
+		switch {
+		case n > longest:
+			candidates, longest = []Suit{s}, n
+		case n == longest:
+			candidates = append(candidates, s)
+		}
+	}
+	if len(candidates) == 0 {
+		return bottom(v.trumps, v.mine[v.trumps])
+	}
+	s := candidates[ai.rng.Intn(len(candidates))]
+	return bottom(s, v.mine[s])
+}
+
+// follow chooses my card to a trick someone else has led.
+func (v *declarerView) follow() Card {
+	p := v.p
+	t := p.CurrentTrick()
+	legal := p.LegalCards()
+	led := t.LedSuit()
+	winner, _ := p.Winning()
+	ours := winner == v.pd
+	winning := t.Cards[t.winningIndex(v.trumps)]
+
+	if legal[0].Suit != led {
+		if ours && (len(t.Cards) == 3 || v.isMaster(winning)) {
+			return v.discard(legal)
+		}
+		if c, ok := cheapestWinner(t, legal, v.trumps); ok {
+			return c
+		}
+		return v.discard(legal)
+	}
+
+	switch len(t.Cards) {
+	case 1: // Second hand: partner plays last
+		if v.partner[led] != 0 && v.isMaster(top(led, v.partner[led])) && top(led, v.partner[led]).Rank > winning.Rank {
+			return lowestCard(legal, v.trumps)
+		}
+		if c, ok := v.cheapestMaster(led, winning); ok {
+			return c
+		}
+	case 2: // Third hand: one defender still to play
+		if ours && v.isMaster(winning) {
+			return lowestCard(legal, v.trumps)
+		}
+		if winning.Suit == led {
+			if f, ok := v.finesseCard(led, v.mine[led]); ok && f.Rank > winning.Rank {
+				return v.lowestEquivalent(f)
+			}
+		}
+		if c, ok := v.cheapestMaster(led, winning); ok {
+			return c
+		}
+	case 3: // Fourth hand
+		if ours {
+			return lowestCard(legal, v.trumps)
+		}
+		if c, ok := cheapestWinner(t, legal, v.trumps); ok {
+			return c
+		}
+	}
+	return lowestCard(legal, v.trumps)
+}
+
+// cheapestMaster returns my lowest master in the suit that beats the
+// winning card, unless the trick is already ruffed.
+func (v *declarerView) cheapestMaster(s Suit, winning Card) (Card, bool) {
+	if winning.Suit != s || v.mine[s] == 0 {
+		return Card{}, false
+	}
+	high := top(s, v.mine[s])
+	if !v.isMaster(high) || high.Rank < winning.Rank {
+		return Card{}, false
+	}
+	return v.lowestEquivalent(high), true
+}
+
+// lowestEquivalent returns my lowest card that wins exactly when c does:
+// no card the defenders hold lies between them.
+func (v *declarerView) lowestEquivalent(c Card) Card {
+	for r := c.Rank - 1; r >= Two; r-- {
+		if v.unseen[c.Suit]>>r&1 == 1 {
+			break
+		}
+		if v.mine[c.Suit]>>r&1 == 1 {
+			c.Rank = r
+		}
+	}
+	return c
+}
+
+// discard throws the least useful card: a low card that is not a winner,
+// keeping trumps, otherwise the cheapest card.
+func (v *declarerView) discard(legal []Card) Card {
+	var best Card
+	found := false
+	for _, c := range legal {
+		if c.Suit == v.trumps || v.isMaster(c) {
+			continue
+		}
+		if !found || c.Rank < best.Rank {
+			best, found = c, true
+		}
+	}
+	if found {
+		return best
+	}
+	return lowestCard(legal, v.trumps)
+}
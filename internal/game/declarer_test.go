@@ -0,0 +1,75 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+// declarerPlay starts the play of a contract by South with South on lead,
+// as if the first trick had just been won.
+func declarerPlay(t *testing.T, pbn string, strain Suit) *Play {
+	t.Helper()
+	d, err := ParsePBNDeal(pbn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewPlay(Contract{Level: 4, Strain: strain, Declarer: South}, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Turn = South
+	return p
+}
+
+func TestDeclarerAI_Lead(t *testing.T) {
+	tests := []struct {
+		name   string
+		pbn    string
+		strain Suit
+		want   Card
+	}{
+		{"draws trumps", "N:6432.K54.K54.654 T98.QJT.QJT.AKQJ AKQJ5.A32.A32.32 7.9876.9876.T987", Spades, Card{Spades, Ace}},
+		{"ruffs in dummy first", "N:6432.K654.K8765. T9.QJT.QJT.AKQJT AKQJ5.A32.A32.32 87.987.94.987654", Spades, Card{Clubs, Two}},
+		{"sets up a long suit", "N:654.76543.76543. AT9.KQJ.KQJ.AKQJ KQJ32.A2.A2.5432 87.T98.T98.T9876", NoTrump, Card{Spades, King}},
+		{"leads towards a tenace", "N:432.AQ5.5432.AKQ JT98.KJT.JT9.JT9 AKQ.432.AKQ.5432 765.9876.876.876", NoTrump, Card{Hearts, Two}},
+	}
+	for _, tt := range tests {
+		p := declarerPlay(t, tt.pbn, tt.strain)
+		if got := NewDeclarerAI(1).ChooseCard(p); got != tt.want {
+			t.Errorf("%s: led %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeclarerAI_Finesse(t *testing.T) {
+	p := declarerPlay(t, "N:432.AQ5.5432.AKQ JT98.KJT.JT9.JT9 AKQ.432.AKQ.5432 765.9876.876.876", NoTrump)
+	ai := NewDeclarerAI(1)
+	for _, c := range []Card{{Hearts, Two}, {Hearts, Six}} {
+		if err := p.PlayCard(p.Turn, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := ai.ChooseCard(p); got != (Card{Hearts, Queen}) {
+		t.Errorf("dummy played %s after West played low, want the queen", got)
+	}
+}
+
+func TestDeclarerAI_FullHand(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		var plays [2][]Card
+		for i := range plays {
+			c := Contract{Level: 3, Strain: Suit(seed % 5), Declarer: Position(seed % 4)}
+			p, _ := NewPlay(c, DealFromSeed(seed))
+			ai := NewDeclarerAI(seed)
+			for !p.IsOver() {
+				if err := p.PlayCard(p.Turn, ai.ChooseCard(p)); err != nil {
+					t.Fatalf("seed %d: ChooseCard() picked an illegal card: %v", seed, err)
+				}
+			}
+			plays[i] = p.Cards()
+		}
+		if !reflect.DeepEqual(plays[0], plays[1]) {
+			t.Errorf("seed %d: the same seed played the hand differently", seed)
+		}
+	}
+}
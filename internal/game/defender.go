@@ -0,0 +1,462 @@
+package game
+
+// DefenderAI plays the cards of the two defenders. Each defender sees their
+// own hand, dummy once it is down, the cards played so far and the auction.
+// The defence follows the usual rules: standard opening leads, second hand
+// low, third hand high, and signals to partner. When partner leads a suit a
+// high spot card encourages it and a low one discourages it; when declarer
+// leads, high-low shows an even number of cards; the first discard of a high
+// spot card asks for that suit; and the card led for partner to ruff shows
+// where our entry lies, high for the higher side suit.
+type DefenderAI struct {
+	auction *Auction
+}
+
+// NewDefenderAI returns defenders who know the auction, which may be nil.
+func NewDefenderAI(auction *Auction) *DefenderAI {
+	return &DefenderAI{auction: auction}
+}
+
+// ChooseCard picks a card for the seat on turn. The defenders' cards follow
+// the rules above; declarer's and dummy's fall back to ChooseCard. The play
+// must not be over.
+func (ai *DefenderAI) ChooseCard(p *Play) Card {
+	if p.Turn.Side() == p.Contract.Declarer.Side() {
+		return ChooseCard(p)
+	}
+	if len(p.Tricks) == 0 {
+		return OpeningLead(p.Hands[p.Turn], p.Contract, ai.auction)
+	}
+	v := newDefenderView(p)
+	if p.CurrentTrick() == nil {
+		return v.lead()
+	}
+	return v.follow()
+}
+
+// OpeningLead chooses the opening lead against a contract from the hand on
+// lead: partner's suit if partner bid one, then the top of the highest
+// sequence of honours, then a singleton against a suit contract, and
+// otherwise the fourth best card of the longest suit the opponents have not
+// bid.
+func OpeningLead(hand *Hand, c Contract, auction *Auction) Card {
+	leader := c.OpeningLeader()
+	var bidBy [4]Side
+	var bid [4]bool
+	if auction != nil {
+		for _, b := range auction.Bids {
+			if b.Level > 0 && b.Strain < NoTrump && !bid[b.Strain] {
+				bid[b.Strain], bidBy[b.Strain] = true, b.Position.Side()
+			}
+		}
+		// Partner's suit is the last one partner named.
+		for i := len(auction.Bids) - 1; i >= 0; i-- {
+			b := auction.Bids[i]
+			if b.Position == leader.Partner() && b.Level > 0 && b.Strain < NoTrump && b.Strain != c.Strain && hand.SuitCount(b.Strain) > 0 {
+				return leadFromSuit(hand.Suit(b.Strain), c.Strain)
+			}
+		}
+	}
+
+	// Side suits only, unless trumps are all we hold.
+	var suits []Suit
+	for s := Clubs; s <= Spades; s++ {
+		if hand.SuitCount(s) > 0 && s != c.Strain {
+			suits = append(suits, s)
+		}
+	}
+	if len(suits) == 0 {
+		return leadFromSuit(hand.Suit(c.Strain), c.Strain)
+	}
+	theirs := func(s Suit) bool { return bid[s] && bidBy[s] == c.Declarer.Side() }
+
+	var sequence []Card
+	for _, s := range suits {
+		cards := hand.Suit(s)
+		if len(cards) >= 2 && isSequence(cards) && !theirs(s) && (sequence == nil || cards[0].Rank > sequence[0].Rank) {
+			sequence = cards
+		}
+	}
+	if sequence != nil {
+		return sequence[0]
+	}
+	if c.Strain != NoTrump && hand.SuitCount(c.Strain) > 0 {
+		for _, s := range suits {
+			if cards := hand.Suit(s); len(cards) == 1 && cards[0].Rank < Ace && !theirs(s) {
+				return cards[0]
+			}
+		}
+	}
+	best := Suit(-1)
+	for _, s := range suits {
+		if best < 0 || theirs(best) && !theirs(s) ||
+			theirs(best) == theirs(s) && hand.SuitCount(s) > hand.SuitCount(best) {
+			best = s
+		}
+	}
+	return leadFromSuit(hand.Suit(best), c.Strain)
+}
+
+// isSequence reports whether a holding, highest first, is headed by two
+// touching honours.
+func isSequence(cards []Card) bool {
+	return cards[0].Rank >= Ten && cards[1].Rank == cards[0].Rank-1
+}
+
+// leadFromSuit chooses which card of a suit to lead: the top of a sequence
+// or of a doubleton, fourth best from four or more, low from three to an
+// honour and top from three small. Against a suit contract an ace is led
+// rather than underled.
+func leadFromSuit(cards []Card, trumps Suit) Card {
+	switch {
+	case len(cards) == 1:
+		return cards[0]
+	case isSequence(cards):
+		return cards[0]
+	case trumps != NoTrump && cards[0].Rank == Ace:
+		return cards[0]
+	case len(cards) >= 4:
+		return cards[3]
+	case len(cards) == 3 && cards[0].Rank >= Ten:
+		return cards[2]
+	}
+	return cards[0]
+}
+
+// defenderView is what a defender knows when choosing a card for me, the
+// seat on turn: my cards, dummy's, and the cards that declarer and partner
+// still hold between them, by suit as bitmasks of ranks.
+type defenderView struct {
+	p        *Play
+	trumps   Suit
+	me, pd   Position
+	dummyPos Position
+	mine     [4]uint16
+	dummy    [4]uint16
+	unseen   [4]uint16
+}
+
+func newDefenderView(p *Play) *defenderView {
+	v := &defenderView{p: p, trumps: p.Trumps(), me: p.Turn, pd: p.Turn.Partner(), dummyPos: p.Contract.Dummy()}
+	for _, c := range p.Hands[v.me].Cards {
+		v.mine[c.Suit] |= 1 << c.Rank
+	}
+	for _, c := range p.Hands[v.dummyPos].Cards {
+		v.dummy[c.Suit] |= 1 << c.Rank
+	}
+	var played [4]uint16
+	for _, c := range p.Cards() {
+		played[c.Suit] |= 1 << c.Rank
+	}
+	for s := range v.unseen {
+		v.unseen[s] = allRanks &^ v.mine[s] &^ v.dummy[s] &^ played[s]
+	}
+	return v
+}
+
+// beaten reports whether a card can still be beaten in its suit by a card
+// I cannot see or by one of dummy's.
+func (v *defenderView) beaten(c Card) bool {
+	return (v.unseen[c.Suit]|v.dummy[c.Suit])>>(c.Rank+1) != 0
+}
+
+// showedOut reports whether pos has failed to follow to a lead of suit s.
+func (v *defenderView) showedOut(pos Position, s Suit) bool {
+	for _, t := range v.p.Tricks {
+		if len(t.Cards) == 0 || t.LedSuit() != s {
+			continue
+		}
+		for i, c := range t.Cards {
+			if t.PlayedBy(i) == pos && c.Suit != s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// partnerSignal returns partner's attitude to suit s: 1 if partner
+// encouraged it, -1 if partner discouraged it and 0 if partner has not
+// said. Partner signals with the card played when I first led the suit,
+// and with their first discard.
+func (v *defenderView) partnerSignal(s Suit) int {
+	discarded := false
+	for _, t := range v.p.Tricks {
+		if len(t.Cards) == 0 {
+			continue
+		}
+		for i, c := range t.Cards {
+			if t.PlayedBy(i) != v.pd {
+				continue
+			}
+			switch {
+			case c.Suit != t.LedSuit() && c.Suit != v.trumps && !discarded:
+				discarded = true
+				if c.Suit == s {
+					return attitude(c)
+				}
+				if attitude(c) > 0 {
+					return -1 // Partner asked for another suit
+				}
+			case t.Leader == v.me && t.LedSuit() == s && c.Suit == s && t.Cards[0].Rank > c.Rank:
+				return attitude(c)
+			}
+		}
+	}
+	return 0
+}
+
+// attitude reads a spot card as encouraging (1) if it is a six or higher,
+// and as discouraging (-1) otherwise.
+func attitude(c Card) int {
+	if c.Rank >= Six {
+		return 1
+	}
+	return -1
+}
+
+// lead chooses my lead after the first trick.
+func (v *defenderView) lead() Card {
+	// Give partner a ruff, showing our entry with the card led.
+	if v.trumps != NoTrump && !v.showedOut(v.pd, v.trumps) {
+		for s := Clubs; s <= Spades; s++ {
+			if s != v.trumps && v.mine[s] != 0 && v.showedOut(v.pd, s) {
+				return v.suitPreference(s)
+			}
+		}
+	}
+
+	// Cash a winner against a suit contract before declarer can throw it
+	// away; partner's suits and our own long suits come next.
+	var candidates []Suit
+	for s := Clubs; s <= Spades; s++ {
+		if v.mine[s] != 0 && (s != v.trumps || v.trumps == NoTrump) {
+			candidates = append(candidates, s)
+		}
+	}
+	if len(candidates) == 0 {
+		return bottom(v.trumps, v.mine[v.trumps])
+	}
+	if v.trumps != NoTrump {
+		for _, s := range candidates {
+			if high := top(s, v.mine[s]); !v.beaten(high) {
+				return high
+			}
+		}
+	}
+	for _, s := range candidates {
+		if v.partnerSignal(s) > 0 || v.ledBy(v.pd, s) && v.partnerSignal(s) == 0 {
+			return v.returnCard(s)
+		}
+	}
+	best := Suit(-1)
+	for _, s := range candidates {
+		if v.partnerSignal(s) < 0 && len(candidates) > 1 {
+			continue
+		}
+		if best < 0 || v.leadScore(s) > v.leadScore(best) {
+			best = s
+		}
+	}
+	if best < 0 {
+		best = candidates[0]
+	}
+	if high := top(best, v.mine[best]); !v.beaten(high) {
+		return high
+	}
+	return leadFromSuit(v.cards(best), v.trumps)
+}
+
+// ledBy reports whether pos has led suit s.
+func (v *defenderView) ledBy(pos Position, s Suit) bool {
+	for _, t := range v.p.Tricks {
+		if len(t.Cards) > 0 && t.Leader == pos && t.LedSuit() == s {
+			return true
+		}
+	}
+	return false
+}
+
+// leadScore rates a suit to lead when partner has not asked for one: our
+// long suits, and suits in which dummy is weak, are best.
+func (v *defenderView) leadScore(s Suit) int {
+	score := count(v.mine[s])
+	if v.dummy[s] == 0 && v.trumps != NoTrump {
+		score -= 3 // Dummy ruffs
+	}
+	if v.dummy[s] != 0 && !v.beaten(top(s, v.dummy[s])) {
+		score -= 2 // Dummy's winners are better left alone
+	}
+	return score
+}
+
+// returnCard leads back partner's suit: the top of a doubleton and low
+// from three or more.
+func (v *defenderView) returnCard(s Suit) Card {
+	if high := top(s, v.mine[s]); !v.beaten(high) || count(v.mine[s]) <= 2 {
+		return high
+	}
+	return bottom(s, v.mine[s])
+}
+
+// suitPreference chooses the card of suit s to lead for partner to ruff:
+// high if our entry is in the higher of the other side suits, low if it is
+// in the lower, and low when we have none.
+func (v *defenderView) suitPreference(s Suit) Card {
+	var others []Suit
+	for o := Clubs; o <= Spades; o++ {
+		if o != s && o != v.trumps {
+			others = append(others, o)
+		}
+	}
+	if len(others) == 2 && v.mine[others[1]] != 0 && !v.beaten(top(others[1], v.mine[others[1]])) {
+		return top(s, v.mine[s])
+	}
+	return bottom(s, v.mine[s])
+}
+
+// cards returns my cards in a suit, highest first.
+func (v *defenderView) cards(s Suit) []Card {
+	return v.p.Hands[v.me].Suit(s)
+}
+
+// follow chooses my card to a trick someone else has led.
+func (v *defenderView) follow() Card {
+	p := v.p
+	t := p.CurrentTrick()
+	legal := p.LegalCards()
+	led := t.LedSuit()
+	winner, _ := p.Winning()
+	winning := t.Cards[t.winningIndex(v.trumps)]
+	partnerLed := t.Leader == v.pd
+
+	if legal[0].Suit != led {
+		partnerWins := winner == v.pd && (len(t.Cards) == 3 || !v.beaten(winning) && v.dummyCannotRuff(led, winning))
+		if !partnerWins {
+			if c, ok := cheapestWinner(t, legal, v.trumps); ok && (len(t.Cards) > 1 || winning.Rank >= Queen) {
+				return c
+			}
+		}
+		return v.discard(legal)
+	}
+
+	switch len(t.Cards) {
+	case 1: // Second hand low, but cover an honour with an honour.
+		if winning.Rank >= Ten && !(t.Leader == v.dummyPos && v.dummy[led]>>(winning.Rank-1)&1 == 1) {
+			if c, ok := cheapestWinner(t, legal, v.trumps); ok && c.Rank >= Jack {
+				return c
+			}
+		}
+		return v.signal(led, false)
+	case 2: // Third hand high, unless partner's card already wins.
+		if winner == v.pd && !v.beaten(winning) {
+			return v.signal(led, true)
+		}
+		high := top(led, v.mine[led])
+		if winning.Suit == led && high.Rank > winning.Rank {
+			return v.lowestEquivalent(high)
+		}
+	case 3:
+		if winner == v.pd {
+			return v.signal(led, partnerLed)
+		}
+		if c, ok := cheapestWinner(t, legal, v.trumps); ok {
+			return c
+		}
+	}
+	return v.signal(led, partnerLed)
+}
+
+// dummyCannotRuff reports whether dummy, still to play, cannot ruff a
+// winning card of the led suit.
+func (v *defenderView) dummyCannotRuff(led Suit, winning Card) bool {
+	t := v.p.CurrentTrick()
+	for i := range t.Cards {
+		if t.PlayedBy(i) == v.dummyPos {
+			return true
+		}
+	}
+	return v.trumps == NoTrump || v.dummy[led] != 0 || v.dummy[v.trumps] == 0 || winning.Suit == v.trumps
+}
+
+// signal chooses a low card to follow suit with: an attitude signal when
+// partner led the suit, high to encourage with an honour, otherwise a count
+// signal, high with an even number of cards.
+func (v *defenderView) signal(s Suit, attitudeSignal bool) Card {
+	cards := v.cards(s)
+	var spots []Card
+	for _, c := range cards {
+		if c.Rank < Ten {
+			spots = append(spots, c)
+		}
+	}
+	if len(spots) < 2 {
+		return cards[len(cards)-1]
+	}
+	high := attitudeSignal && cards[0].Rank >= Queen
+	if !attitudeSignal {
+		high = len(cards)%2 == 0
+	}
+	if high {
+		return spots[0]
+	}
+	return spots[len(spots)-1]
+}
+
+// lowestEquivalent returns my lowest card that wins exactly when c does:
+// no card I cannot see lies between them.
+func (v *defenderView) lowestEquivalent(c Card) Card {
+	for r := c.Rank - 1; r >= Two; r-- {
+		if (v.unseen[c.Suit]|v.dummy[c.Suit])>>r&1 == 1 {
+			break
+		}
+		if v.mine[c.Suit]>>r&1 == 1 {
+			c.Rank = r
+		}
+	}
+	return c
+}
+
+// discard throws a card when I cannot follow suit. The first discard is a
+// signal: a high spot card from a suit headed by the ace or king-queen asks
+// for it. Otherwise the lowest card of the weakest side suit is thrown,
+// keeping stoppers.
+func (v *defenderView) discard(legal []Card) Card {
+	first := true
+	for _, t := range v.p.Tricks {
+		for i, c := range t.Cards {
+			if t.PlayedBy(i) == v.me && c.Suit != t.LedSuit() {
+				first = false
+			}
+		}
+	}
+	if first {
+		for s := Clubs; s <= Spades; s++ {
+			cards := v.cards(s)
+			if s == v.trumps || len(cards) < 3 || !(cards[0].Rank == Ace || isSequence(cards) && cards[0].Rank == King) {
+				continue
+			}
+			if spot := cards[len(cards)-2]; spot.Rank >= Six && spot.Rank < Ten {
+				return spot
+			}
+		}
+	}
+
+	// Keep stoppers: a suit we can no longer stop would run against us.
+	hand := v.p.Hands[v.me]
+	weakest := Suit(-1)
+	for _, c := range legal {
+		if c.Suit == v.trumps || !v.beaten(c) {
+			continue
+		}
+		stopper := hand.HasStopper(c.Suit)
+		if weakest < 0 || !stopper && hand.HasStopper(weakest) ||
+			stopper == hand.HasStopper(weakest) && top(c.Suit, v.mine[c.Suit]).Rank < top(weakest, v.mine[weakest]).Rank {
+			weakest = c.Suit
+		}
+	}
+	if weakest >= 0 {
+		return bottom(weakest, v.mine[weakest])
+	}
+	return lowestCard(legal, v.trumps)
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOpeningLead(t *testing.T) {
+	// North opens 1H, East overcalls 1S, South bids 1NT, passed out: West
+	// leads against 1NT by South, or against 4S by North in the suit tests.
+	auction := &Auction{Bids: []Bid{
+		{Level: 1, Strain: Hearts, Position: North},
+		{Level: 1, Strain: Spades, Position: East},
+		{Level: 1, Strain: NoTrump, Position: South},
+	}}
+	tests := []struct {
+		name     string
+		holding  string
+		contract Contract
+		auction  *Auction
+		want     Card
+	}{
+		{"top of a sequence", "KQJ4.832.T97.J52", Contract{Level: 3, Strain: NoTrump, Declarer: South}, nil, Card{Spades, King}},
+		{"fourth best", "K9764.832.A7.J52", Contract{Level: 3, Strain: NoTrump, Declarer: South}, nil, Card{Spades, Six}},
+		{"partner's suit, low from an honour", "K73.Q832.T976.52", Contract{Level: 1, Strain: NoTrump, Declarer: South}, auction, Card{Spades, Three}},
+		{"partner's suit, top of a doubleton", "82.Q8532.KT97.52", Contract{Level: 1, Strain: NoTrump, Declarer: South}, auction, Card{Spades, Eight}},
+		{"not the opponents' suit", "82.Q8532.KT97.52", Contract{Level: 3, Strain: NoTrump, Declarer: South}, auction, Card{Spades, Eight}},
+		{"singleton against a suit", "832.8.KT976.A752", Contract{Level: 4, Strain: Spades, Declarer: North}, nil, Card{Hearts, Eight}},
+		{"no underlead of an ace", "832.T4.A8642.752", Contract{Level: 4, Strain: Spades, Declarer: North}, nil, Card{Diamonds, Ace}},
+	}
+	for _, tt := range tests {
+		cards, err := parseHolding(tt.holding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := OpeningLead(NewHand(cards), tt.contract, tt.auction); got != tt.want {
+			t.Errorf("%s: OpeningLead(%s) = %s, want %s", tt.name, tt.holding, got, tt.want)
+		}
+	}
+}
+
+// signalDeal has East holding the queen and three spots in spades, four
+// small hearts and three clubs to the king, against 3NT by South.
+const signalDeal = "N:654.AKQ.AKQ2.AQ2 Q872.8742.J5.K53 T9.653.7643.T764 AKJ3.JT9.T98.J98"
+
+func TestDefenderAI_Follow(t *testing.T) {
+	tests := []struct {
+		name   string
+		leader Position
+		played []Card
+		want   Card
+	}{
+		{"encourages partner's suit", West, []Card{{Spades, Ace}, {Spades, Four}}, Card{Spades, Eight}},
+		{"third hand high", West, []Card{{Spades, Three}, {Spades, Four}}, Card{Spades, Queen}},
+		{"shows an even number", North, []Card{{Hearts, Ace}}, Card{Hearts, Eight}},
+		{"shows an odd number", North, []Card{{Clubs, Ace}}, Card{Clubs, Three}},
+	}
+	for _, tt := range tests {
+		d, _ := ParsePBNDeal(signalDeal)
+		p, _ := NewPlay(Contract{Level: 3, Strain: NoTrump, Declarer: South}, d)
+		p.Turn = tt.leader
+		for _, c := range tt.played {
+			if err := p.PlayCard(p.Turn, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := NewDefenderAI(nil).ChooseCard(p); got != tt.want {
+			t.Errorf("%s: East played %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDefenderAI_FullHand(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		var plays [2][]Card
+		for i := range plays {
+			c := Contract{Level: 3, Strain: Suit(seed % 5), Declarer: Position(seed % 4)}
+			p, _ := NewPlay(c, DealFromSeed(seed))
+			declarer, defenders := NewDeclarerAI(seed), NewDefenderAI(nil)
+			for !p.IsOver() {
+				ai := defenders.ChooseCard
+				if p.Turn.Side() == c.Declarer.Side() {
+					ai = declarer.ChooseCard
+				}
+				if err := p.PlayCard(p.Turn, ai(p)); err != nil {
+					t.Fatalf("seed %d: ChooseCard() picked an illegal card: %v", seed, err)
+				}
+			}
+			plays[i] = p.Cards()
+		}
+		if !reflect.DeepEqual(plays[0], plays[1]) {
+			t.Errorf("seed %d: the same deal was defended differently", seed)
+		}
+	}
+}
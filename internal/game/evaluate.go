@@ -0,0 +1,264 @@
+package game
+
+// holding is what a hand holds in one suit.
+type holding struct {
+	length int
+	held   [Ace + 1]bool // Indexed by Rank
+}
+
+// holding returns what the hand holds in a suit.
+func (h *Hand) holding(s Suit) holding {
+	var hd holding
+	for _, c := range h.Cards {
+		if c.Suit == s {
+			hd.length++
+			hd.held[c.Rank] = true
+		}
+	}
+	return hd
+}
+
+// count returns how many of the ranks the holding has.
+func (hd holding) count(ranks ...Rank) int {
+	n := 0
+	for _, r := range ranks {
+		if hd.held[r] {
+			n++
+		}
+	}
+	return n
+}
+
+// HCP returns the hand's high card points: 4 for an ace, 3 for a king, 2
+// for a queen and 1 for a jack.
+func (h *Hand) HCP() int {
+	hcp, _ := handShape(h)
+	return hcp
+}
+
+// ShortSuitPoints returns the points a hand adds for shortness once a fit
+// is found: 3 for a void, 2 for a singleton and 1 for a doubleton.
+func (h *Hand) ShortSuitPoints() int {
+	points := 0
+	for s := Clubs; s <= Spades; s++ {
+		if n := h.SuitCount(s); n < 3 {
+			points += 3 - n
+		}
+	}
+	return points
+}
+
+// LengthPoints returns the points a hand adds for long suits: 1 for each
+// card beyond the fourth in a suit.
+func (h *Hand) LengthPoints() int {
+	points := 0
+	for s := Clubs; s <= Spades; s++ {
+		if n := h.SuitCount(s); n > 4 {
+			points += n - 4
+		}
+	}
+	return points
+}
+
+// LosingTrickCount returns the hand's losers. Only a suit's top three
+// cards can lose, and the ace, king and queen among them do not: a
+// singleton other than the ace loses one, a doubleton loses one for each
+// of the ace and king it lacks, and a longer suit one for each of the
+// ace, king and queen.
+func (h *Hand) LosingTrickCount() int {
+	losers := 0
+	for s := Clubs; s <= Spades; s++ {
+		hd := h.holding(s)
+		switch {
+		case hd.length == 0:
+		case hd.length == 1:
+			losers += 1 - hd.count(Ace)
+		case hd.length == 2:
+			losers += 2 - hd.count(Ace, King)
+		default:
+			losers += 3 - hd.count(Ace, King, Queen)
+		}
+	}
+	return losers
+}
+
+// Controls returns the hand's controls: 2 for an ace and 1 for a king.
+func (h *Hand) Controls() int {
+	controls := 0
+	for _, c := range h.Cards {
+		switch c.Rank {
+		case Ace:
+			controls += 2
+		case King:
+			controls++
+		}
+	}
+	return controls
+}
+
+// ZarPoints returns the hand's Zar points: HCP and controls, the lengths
+// of its two longest suits, and how much longer its longest suit is than
+// its shortest. 26 is an opening hand.
+func (h *Hand) ZarPoints() int {
+	_, lengths := handShape(h)
+	longest, second, shortest := 0, 0, 13
+	for _, n := range lengths {
+		switch {
+		case n > longest:
+			longest, second = n, longest
+		case n > second:
+			second = n
+		}
+		shortest = min(shortest, n)
+	}
+	return h.HCP() + h.Controls() + longest + second + longest - shortest
+}
+
+// QuickTricks returns the tricks the hand's top cards take in the first
+// two rounds of their suits: 2 for the ace and king, 1½ for the ace and
+// queen, 1 for the ace or the king and queen, and ½ for a king with
+// another card.
+func (h *Hand) QuickTricks() float64 {
+	tricks := 0.0
+	for s := Clubs; s <= Spades; s++ {
+		hd := h.holding(s)
+		switch {
+		case hd.held[Ace] && hd.held[King]:
+			tricks += 2
+		case hd.held[Ace] && hd.held[Queen]:
+			tricks += 1.5
+		case hd.held[Ace], hd.held[King] && hd.held[Queen]:
+			tricks++
+		case hd.held[King] && hd.length >= 2:
+			tricks += 0.5
+		}
+	}
+	return tricks
+}
+
+// SuitQuality returns the suit's length plus the number of its top five
+// honours, the ace to the ten. An overcall wants as much as the number of
+// tricks it contracts for.
+func (h *Hand) SuitQuality(s Suit) int {
+	hd := h.holding(s)
+	return hd.length + hd.count(Ace, King, Queen, Jack, Ten)
+}
+
+// KaplanRubens returns the hand's value by the Kaplan-Rubens "four C's"
+// evaluator, on the scale of HCP. Suit by suit it counts:
+//
+//   - honours: 3 for the ace; 2 for the king, ½ singleton; for the queen
+//     1 with the ace or king and ¾ without in a suit of three or more, ½
+//     and ¼ in a doubleton and nothing singleton; for the jack ½ with two
+//     of the ace, king and queen and ¼ with one; and ¼ for the ten with
+//     two higher honours, or with the nine and one;
+//   - quality: the suit's length times its honours over ten, where the
+//     ace, king and queen count 1 and the jack and ten ½, and a 7-card
+//     suit is not short of the queen, an 8-card one the jack, nor a
+//     9-card one the ten;
+//   - shape: 3 for a void, 2 for a singleton and 1 for a doubleton.
+//
+// The first doubleton counts nothing, 4-3-3-3 loses ½, and the total is
+// brought to the scale of HCP by taking a third more.
+func (h *Hand) KaplanRubens() float64 {
+	total, doubletons := 0.0, 0
+	for s := Clubs; s <= Spades; s++ {
+		hd := h.holding(s)
+		n := hd.length
+		if hd.held[Ace] {
+			total += 3
+		}
+		if hd.held[King] {
+			if n == 1 {
+				total += 0.5
+			} else {
+				total += 2
+			}
+		}
+		if hd.held[Queen] {
+			higher := hd.count(Ace, King) > 0
+			switch {
+			case n >= 3 && higher:
+				total++
+			case n >= 3:
+				total += 0.75
+			case n == 2 && higher:
+				total += 0.5
+			case n == 2:
+				total += 0.25
+			}
+		}
+		if hd.held[Jack] {
+			switch hd.count(Ace, King, Queen) {
+			case 0:
+			case 1:
+				total += 0.25
+			default:
+				total += 0.5
+			}
+		}
+		if above := hd.count(Ace, King, Queen, Jack); hd.held[Ten] && (above >= 2 || above == 1 && hd.held[Nine]) {
+			total += 0.25
+		}
+
+		quality := float64(hd.count(Ace, King, Queen)) + 0.5*float64(hd.count(Jack, Ten))
+		if n >= 7 && !hd.held[Queen] {
+			quality++
+		}
+		if n >= 8 && !hd.held[Jack] {
+			quality += 0.5
+		}
+		if n >= 9 && !hd.held[Ten] {
+			quality += 0.5
+		}
+		total += quality * float64(n) / 10
+
+		if n < 3 {
+			total += float64(3 - n)
+		}
+		if n == 2 {
+			doubletons++
+		}
+	}
+	if doubletons > 0 {
+		total--
+	}
+	if _, lengths := handShape(h); isFlat(lengths) {
+		total -= 0.5
+	}
+	return total * 4 / 3
+}
+
+// isFlat reports whether a hand is 4-3-3-3, in any order.
+func isFlat(lengths [4]int) bool {
+	fours := 0
+	for _, n := range lengths {
+		switch n {
+		case 3:
+		case 4:
+			fours++
+		default:
+			return false
+		}
+	}
+	return fours == 1
+}
+
+// handMetric is a way of valuing a hand that a rule's points may name.
+type handMetric struct {
+	Label string              // How explanations write it, e.g. "losers"
+	Max   int                 // At least the most any hand can have
+	Value func(*Hand) float64 // The hand's value
+}
+
+// handMetrics are the metrics a rule's points may name, by name.
+var handMetrics = map[string]handMetric{
+	"hcp":           {"HCP", 37, func(h *Hand) float64 { return float64(h.HCP()) }},
+	"hcp+length":    {"points with length", 46, func(h *Hand) float64 { return float64(h.HCP() + h.LengthPoints()) }},
+	"hcp+shortness": {"points with shortness", 46, func(h *Hand) float64 { return float64(h.HCP() + h.ShortSuitPoints()) }},
+	"ltc":           {"losers", 12, func(h *Hand) float64 { return float64(h.LosingTrickCount()) }},
+	"zar":           {"Zar points", 99, func(h *Hand) float64 { return float64(h.ZarPoints()) }},
+	"cccc":          {"CCCC points", 50, (*Hand).KaplanRubens},
+	"quick-tricks":  {"quick tricks", 8, (*Hand).QuickTricks},
+	"controls":      {"controls", 12, func(h *Hand) float64 { return float64(h.Controls()) }},
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHand_Metrics(t *testing.T) {
+	tests := []struct {
+		hand                           string
+		hcp, shortness, length, losers int
+		controls, zar                  int
+		quickTricks, cccc              float64
+		spadeQuality                   int
+	}{
+		{"AQ3.KJ4.K765.Q43", 15, 0, 0, 7, 4, 27, 2.5, 13.6667, 5},
+		{"KQJ9832.54..A432", 10, 4, 3, 5, 3, 31, 2, 15.5333, 10},
+		{"AK.AK.AK.AKQJT98", 31, 3, 3, 0, 12, 57, 8, 37, 4},
+		{"5432.432.432.432", 0, 0, 0, 12, 0, 8, 0, -0.6667, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hand, func(t *testing.T) {
+			h := systemPlayer(t, North, tt.hand).Hand
+			ints := []struct {
+				name      string
+				got, want int
+			}{
+				{"HCP()", h.HCP(), tt.hcp},
+				{"ShortSuitPoints()", h.ShortSuitPoints(), tt.shortness},
+				{"LengthPoints()", h.LengthPoints(), tt.length},
+				{"LosingTrickCount()", h.LosingTrickCount(), tt.losers},
+				{"Controls()", h.Controls(), tt.controls},
+				{"ZarPoints()", h.ZarPoints(), tt.zar},
+				{"SuitQuality(Spades)", h.SuitQuality(Spades), tt.spadeQuality},
+			}
+			for _, m := range ints {
+				if m.got != m.want {
+					t.Errorf("%s = %d, want %d", m.name, m.got, m.want)
+				}
+			}
+			if got := h.QuickTricks(); got != tt.quickTricks {
+				t.Errorf("QuickTricks() = %g, want %g", got, tt.quickTricks)
+			}
+			if got := h.KaplanRubens(); math.Abs(got-tt.cccc) > 0.001 {
+				t.Errorf("KaplanRubens() = %.4f, want %.4f", got, tt.cccc)
+			}
+		})
+	}
+}
+
+func TestHandConstraint_Points(t *testing.T) {
+	sys, err := ParseSystem([]byte(`{
+		"name": "Metrics",
+		"rules": [
+			{"context": "opening", "hand": {"points": {"zar": [26, 99]}, "suitQuality": {"S": [9, 13]}}, "call": "1S"},
+			{"context": "opening", "hand": {"points": {"ltc": [0, 7], "quick-tricks": [2.5, 8]}}, "call": "1C", "meaning": "Rule of losers"},
+			{"context": "opening", "hand": {"points": {"quick-tricks": [1.5, 8], "cccc": [10.5, 50]}}, "call": "1D"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("zar and suit quality", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{
+			// Spades (7): K Q J 9 8 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Eight}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds: void
+			// Clubs (4): A 4 3 2 -> 4 HCP
+			{Suit: Clubs, Rank: Ace}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		expectCall(t, opener, NewAuction(), "1S", sys)
+	})
+
+	t.Run("losers and quick tricks", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): Q 4 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		bid := expectCall(t, opener, NewAuction(), "1C", sys)
+		if got, want := bid.Explanation.String(), "Rule of losers (0-7 losers, 2.5+ quick tricks)"; got != want {
+			t.Errorf("explanation = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("fractional bounds", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{ // 1.5 quick tricks and 10.67 CCCC points
+			// Spades (3): K Q 3 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): Q 7 6 5 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): Q 4 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		expectCall(t, opener, NewAuction(), "1D", sys)
+	})
+
+	t.Run("CCCC points below a bound", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{ // 10.07 CCCC points
+			// Spades (3): K Q 3 -> 5 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): Q J 4 -> 3 HCP
+			{Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K J 6 5 -> 4 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): J 4 3 -> 1 HCP
+			{Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		expectCall(t, opener, NewAuction(), "Pass", sys)
+	})
+
+	t.Run("too many losers", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{
+			// Spades (3): Q 4 3 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K J 4 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): Q 4 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		expectCall(t, opener, NewAuction(), "Pass", sys)
+	})
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Explanation is what a call shows, as the bidding system that made it
+// describes it. Only what the system's rule limits is given: HCP is nil
+// and Lengths leaves a suit out when the call says nothing about it.
+type Explanation struct {
+	Meaning  string                // In words, e.g. "Jacoby transfer: 5+ hearts"
+	HCP      *Range                // High card points shown
+	Points   map[string]FloatRange // Values shown by other metrics, e.g. "ltc", by name
+	Lengths  map[Suit]Range        // Suit lengths shown
+	Balanced bool                  // The hand is balanced
+	Forcing  bool                  // Partner may not pass
+	Alert    bool                  // Artificial or conventional, so the opponents are told
+}
+
+// suitNames are the suits' names in the plural, indexed by Suit.
+var suitNames = [4]string{"clubs", "diamonds", "hearts", "spades"}
+
+// String describes the call in a line, e.g. "Alert! Jacoby transfer: 5+
+// hearts (0-7 HCP, 5+ hearts, forcing)".
+func (e *Explanation) String() string {
+	var details []string
+	if e.HCP != nil {
+		details = append(details, formatRange(*e.HCP, 37)+" HCP")
+	}
+	names := make([]string, 0, len(e.Points))
+	for name := range e.Points {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		m := handMetrics[name]
+		details = append(details, formatFloatRange(e.Points[name], m.Max)+" "+m.Label)
+	}
+	for s := Spades; s >= Clubs; s-- {
+		if r, ok := e.Lengths[s]; ok {
+			details = append(details, formatRange(r, 13)+" "+suitNames[s])
+		}
+	}
+	if e.Balanced {
+		details = append(details, "balanced")
+	}
+	if e.Forcing {
+		details = append(details, "forcing")
+	}
+	var b strings.Builder
+	if e.Alert {
+		b.WriteString("Alert! ")
+	}
+	b.WriteString(e.Meaning)
+	if len(details) > 0 {
+		if e.Meaning != "" {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(&b, "(%s)", strings.Join(details, ", "))
+	}
+	return b.String()
+}
+
+// formatRange writes a range as "5", "5-7" or, reaching max, "5+".
+func formatRange(r Range, max int) string {
+	switch {
+	case r.Min == r.Max:
+		return fmt.Sprint(r.Min)
+	case r.Max >= max:
+		return fmt.Sprintf("%d+", r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// formatFloatRange writes a range of numbers as formatRange does, e.g.
+// "1.5+".
+func formatFloatRange(r FloatRange, max int) string {
+	switch {
+	case r.Min == r.Max:
+		return fmt.Sprintf("%g", r.Min)
+	case r.Max >= float64(max):
+		return fmt.Sprintf("%g+", r.Min)
+	}
+	return fmt.Sprintf("%g-%g", r.Min, r.Max)
+}
+
+// explain describes the call a rule makes, with its suit variables bound
+// as they were for the hand that made it. partner is what partner had
+// shown then, which turns a combined HCP range into the hand's own.
+func (r *Rule) explain(vars *bindings, partner HandPicture) *Explanation {
+	e := &Explanation{Meaning: r.Meaning, Forcing: r.Forcing, Alert: r.Alert}
+	c := &r.Hand
+	hcp := Range{0, 37}
+	if c.HCP != nil {
+		hcp = *c.HCP
+	}
+	if c.Combined != nil {
+		hcp = narrow(hcp, Range{c.Combined.Min - partner.HCP.Min, c.Combined.Max - partner.HCP.Min})
+	}
+	if hcp.Min > 0 || hcp.Max < 37 {
+		hcp.Min, hcp.Max = max(hcp.Min, 0), min(hcp.Max, 37)
+		e.HCP = &hcp
+	}
+	for name, r := range c.Points {
+		if e.Points == nil {
+			e.Points = make(map[string]FloatRange)
+		}
+		e.Points[name] = r
+	}
+	if c.Balanced != nil {
+		e.Balanced = *c.Balanced
+	}
+	show := func(s Suit, l Range) {
+		if e.Lengths == nil {
+			e.Lengths = make(map[Suit]Range)
+		}
+		if prev, ok := e.Lengths[s]; ok {
+			l.Min, l.Max = max(l.Min, prev.Min), min(l.Max, prev.Max)
+		}
+		e.Lengths[s] = l
+	}
+	for s := Clubs; s <= Spades; s++ {
+		if l := c.suitRange(s); l != nil {
+			show(s, *l)
+		}
+	}
+	for name, l := range c.Lengths {
+		if v := variableIndex(name); v >= 0 && vars.bound[v] {
+			show(vars.suit[v], l)
+		}
+	}
+	for s, l := range e.Lengths {
+		if l.Min == 0 && l.Max >= 13 {
+			delete(e.Lengths, s)
+		}
+	}
+	return e
+}
@@ -0,0 +1,306 @@
+package game
+
+import "testing"
+
+func TestExplanation_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    Explanation
+		want string
+	}{
+		{"meaning only", Explanation{Meaning: "To play"}, "To play"},
+		{
+			"alert and forcing",
+			Explanation{Meaning: "Jacoby transfer", HCP: &Range{0, 37}, Lengths: map[Suit]Range{Hearts: {5, 13}}, Forcing: true, Alert: true},
+			"Alert! Jacoby transfer (0+ HCP, 5+ hearts, forcing)",
+		},
+		{
+			"ranges, spades first",
+			Explanation{HCP: &Range{11, 14}, Lengths: map[Suit]Range{Clubs: {0, 4}, Spades: {6, 6}}, Balanced: true},
+			"(11-14 HCP, 6 spades, 0-4 clubs, balanced)",
+		},
+		{"nothing shown", Explanation{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSystem_CallsAreExplained(t *testing.T) {
+	t.Run("transfer", func(t *testing.T) {
+		responder := NewPlayer(South)
+		responder.Hand = NewHand([]Card{
+			// Spades (2): Q 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Two},
+			// Hearts (5): K J 7 6 5 -> 4 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Jack}, {Suit: Hearts, Rank: Seven}, {Suit: Hearts, Rank: Six}, {Suit: Hearts, Rank: Five},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (3): 4 3 2
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: NoTrump, Position: North})
+		auction.AddBid(Bid{Pass: true, Position: East})
+
+		bid := expectCall(t, responder, auction, "2D", PolishClub)
+		checkExplanation(t, bid.Explanation, "Jacoby transfer: 5+ hearts", &Range{5, 37}, map[Suit]Range{Hearts: {5, 13}}, true, true)
+	})
+
+	t.Run("opening", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K 5 4 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): Q 7 6 5 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): Q 4 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+
+		bid := expectCall(t, opener, auction, "1C", PolishClub)
+		checkExplanation(t, bid.Explanation, "Weak club: 11-14 HCP balanced, no 5-card major", &Range{11, 14}, map[Suit]Range{Spades: {0, 4}, Hearts: {0, 4}}, true, true)
+	})
+
+	t.Run("suit bound by the hand", func(t *testing.T) {
+		overcaller := NewPlayer(East)
+		overcaller.Hand = NewHand([]Card{
+			// Spades (6): K Q J 9 3 2 -> 6 HCP
+			{Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}, {Suit: Spades, Rank: Nine}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (3): 8 7 6
+			{Suit: Diamonds, Rank: Eight}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six},
+			// Clubs (2): 4 3
+			{Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		bid := expectCall(t, overcaller, auction, "2S", PolishClub)
+		checkExplanation(t, bid.Explanation, "Weak jump overcall: 5-10 HCP, a good 6+ card suit", &Range{5, 10}, map[Suit]Range{Spades: {6, 13}}, false, false)
+	})
+
+	t.Run("suit bound by the auction", func(t *testing.T) {
+		advancer := NewPlayer(West)
+		advancer.Hand = NewHand([]Card{
+			// Spades (3): Q 4 3 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): K 7 6 5 -> 3 HCP
+			{Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): J 4 3 -> 1 HCP
+			{Suit: Clubs, Rank: Jack}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Pass: true, Position: South})
+
+		bid := expectCall(t, advancer, auction, "2S", PolishClub)
+		checkExplanation(t, bid.Explanation, "Raise: 6-9 HCP, 3+ card support", &Range{6, 9}, map[Suit]Range{Spades: {3, 13}}, false, false)
+	})
+
+	t.Run("cue-bid raise once they bid again", func(t *testing.T) {
+		advancer := NewPlayer(West)
+		advancer.Hand = NewHand([]Card{
+			// Spades (3): Q 4 3 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Four}, {Suit: Spades, Rank: Three},
+			// Hearts (3): 5 4 3
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Three},
+			// Diamonds (4): A K 6 5 -> 7 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: King}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): K 4 3 -> 3 HCP
+			{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+		auction.AddBid(Bid{Level: 1, Strain: Spades, Position: East})
+		auction.AddBid(Bid{Level: 2, Strain: Hearts, Position: South})
+
+		bid := expectCall(t, advancer, auction, "3H", PolishClub)
+		checkExplanation(t, bid.Explanation, "Cue-bid raise: 11+ HCP, 3+ card support", &Range{11, 37}, map[Suit]Range{Spades: {3, 13}}, false, true)
+	})
+}
+
+// checkExplanation compares what a call was explained as with what its
+// rule shows.
+func checkExplanation(t *testing.T, e *Explanation, meaning string, hcp *Range, lengths map[Suit]Range, forcing, alert bool) {
+	t.Helper()
+	if e == nil {
+		t.Fatal("no explanation")
+	}
+	if e.Meaning != meaning || e.Forcing != forcing || e.Alert != alert {
+		t.Errorf("explanation = %q, forcing %v, alert %v; want %q, %v, %v", e.Meaning, e.Forcing, e.Alert, meaning, forcing, alert)
+	}
+	if (e.HCP == nil) != (hcp == nil) || e.HCP != nil && *e.HCP != *hcp {
+		t.Errorf("HCP = %v, want %v", e.HCP, hcp)
+	}
+	if len(e.Lengths) != len(lengths) {
+		t.Errorf("lengths = %v, want %v", e.Lengths, lengths)
+	}
+	for s, r := range lengths {
+		if e.Lengths[s] != r {
+			t.Errorf("%s = %v, want %v", suitNames[s], e.Lengths[s], r)
+		}
+	}
+}
+
+func TestPlayer_ExplainBid(t *testing.T) {
+	t.Run("rule fitting the hand", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{
+			// Spades (3): A Q 3 -> 6 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K 5 4 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): Q 7 6 5 -> 2 HCP
+			{Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (3): Q 4 3 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three},
+		})
+
+		auction := NewAuction()
+
+		e := opener.ExplainBid(auction, NewBid(1, Clubs))
+		if e == nil {
+			t.Fatalf("ExplainBid(1C) = nil, want %q", "Weak club: 11-14 HCP balanced, no 5-card major")
+		}
+		if e.Meaning != "Weak club: 11-14 HCP balanced, no 5-card major" {
+			t.Errorf("ExplainBid(1C) = %q, want %q", e.Meaning, "Weak club: 11-14 HCP balanced, no 5-card major")
+		}
+	})
+
+	t.Run("strong hand", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{
+			// Spades (4): A K Q 3 -> 9 HCP
+			{Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: King}, {Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three},
+			// Hearts (3): K 5 4 -> 3 HCP
+			{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): A Q 6 5 -> 6 HCP
+			{Suit: Diamonds, Rank: Ace}, {Suit: Diamonds, Rank: Queen}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (2): Q 4 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four},
+		})
+
+		auction := NewAuction()
+
+		e := opener.ExplainBid(auction, NewBid(1, Clubs))
+		if e == nil {
+			t.Fatalf("ExplainBid(1C) = nil, want %q", "Strong club: 18+ HCP, any shape")
+		}
+		if e.Meaning != "Strong club: 18+ HCP, any shape" {
+			t.Errorf("ExplainBid(1C) = %q, want %q", e.Meaning, "Strong club: 18+ HCP, any shape")
+		}
+	})
+
+	t.Run("the first rule for a hand that fits none", func(t *testing.T) {
+		opener := NewPlayer(North)
+		opener.Hand = NewHand([]Card{
+			// Spades (3): Q 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): J 7 6 5 -> 1 HCP
+			{Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (4): Q 4 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+
+		e := opener.ExplainBid(auction, NewBid(1, Clubs))
+		if e == nil {
+			t.Fatalf("ExplainBid(1C) = nil, want %q", "Strong club: 18+ HCP, any shape")
+		}
+		if e.Meaning != "Strong club: 18+ HCP, any shape" {
+			t.Errorf("ExplainBid(1C) = %q, want %q", e.Meaning, "Strong club: 18+ HCP, any shape")
+		}
+	})
+
+	t.Run("suit variable taken from the call", func(t *testing.T) {
+		overcaller := NewPlayer(East)
+		overcaller.Hand = NewHand([]Card{
+			// Spades (3): Q 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): J 7 6 5 -> 1 HCP
+			{Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (4): Q 4 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		e := overcaller.ExplainBid(auction, NewBid(2, Diamonds))
+		if e == nil {
+			t.Fatalf("ExplainBid(2D) = nil, want %q", "10-16 HCP, 5+ cards")
+		}
+		if e.Meaning != "10-16 HCP, 5+ cards" {
+			t.Errorf("ExplainBid(2D) = %q, want %q", e.Meaning, "10-16 HCP, 5+ cards")
+		}
+	})
+
+	t.Run("jump", func(t *testing.T) {
+		overcaller := NewPlayer(East)
+		overcaller.Hand = NewHand([]Card{
+			// Spades (3): Q 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): J 7 6 5 -> 1 HCP
+			{Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (4): Q 4 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		e := overcaller.ExplainBid(auction, NewBid(3, Diamonds))
+		if e == nil {
+			t.Fatalf("ExplainBid(3D) = nil, want %q", "Weak jump overcall: 5-10 HCP, a good 6+ card suit")
+		}
+		if e.Meaning != "Weak jump overcall: 5-10 HCP, a good 6+ card suit" {
+			t.Errorf("ExplainBid(3D) = %q, want %q", e.Meaning, "Weak jump overcall: 5-10 HCP, a good 6+ card suit")
+		}
+	})
+
+	t.Run("no rule makes the call", func(t *testing.T) {
+		overcaller := NewPlayer(East)
+		overcaller.Hand = NewHand([]Card{
+			// Spades (3): Q 3 2 -> 2 HCP
+			{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Three}, {Suit: Spades, Rank: Two},
+			// Hearts (2): 5 4
+			{Suit: Hearts, Rank: Five}, {Suit: Hearts, Rank: Four},
+			// Diamonds (4): J 7 6 5 -> 1 HCP
+			{Suit: Diamonds, Rank: Jack}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Six}, {Suit: Diamonds, Rank: Five},
+			// Clubs (4): Q 4 3 2 -> 2 HCP
+			{Suit: Clubs, Rank: Queen}, {Suit: Clubs, Rank: Four}, {Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two},
+		})
+
+		auction := NewAuction()
+		auction.AddBid(Bid{Level: 1, Strain: Hearts, Position: North})
+
+		e := overcaller.ExplainBid(auction, NewBid(4, Diamonds))
+		if e != nil {
+			t.Errorf("ExplainBid(4D) = %q, want no explanation", e.Meaning)
+		}
+	})
+}
@@ -28,37 +28,15 @@ func (h *Hand) Sort() {
 	})
 }
 
-// Evaluate evaluates the hand and returns the high card points and distribution
+// Evaluate evaluates the hand and returns the high card points and
+// distribution. The other ways of valuing a hand, such as LengthPoints,
+// LosingTrickCount or ZarPoints, are in evaluate.go.
 func (h *Hand) Evaluate() (hcp int, distribution map[Suit]int) {
 	distribution = make(map[Suit]int)
-	
-	// Count high card points and distribution
 	for _, card := range h.Cards {
-		switch card.Rank {
-		case Ace:
-			hcp += 4
-		case King:
-			hcp += 3
-		case Queen:
-			hcp += 2
-		case Jack:
-			hcp += 1
-		}
 		distribution[card.Suit]++
 	}
-	
-	// Add distribution points (optional, can be part of the bidding strategy)
-	// for _, count := range distribution {
-	//     if count == 2 {
-	//         hcp += 1 // Doubleton
-	//     } else if count == 1 {
-	//         hcp += 2 // Singleton
-	//     } else if count == 0 {
-	//         hcp += 3 // Void
-	//     }
-	// }
-	
-	return hcp, distribution
+	return h.HCP(), distribution
 }
 
 // SuitCount returns the number of cards in the specified suit
@@ -97,6 +75,18 @@ func (h *Hand) HasStopper(s Suit) bool {
 	return hasAce || (hasKing && count >= 2) || (hasQueen && count >= 3)
 }
 
+// TopHonours returns how many of the ace, king and queen of a suit the
+// hand holds.
+func (h *Hand) TopHonours(s Suit) int {
+	n := 0
+	for _, card := range h.Cards {
+		if card.Suit == s && card.Rank >= Queen {
+			n++
+		}
+	}
+	return n
+}
+
 // GetSuit returns a string representation of cards in the specified suit
 func (h *Hand) GetSuit(s Suit) string {
 	var cards []string
@@ -123,3 +113,35 @@ func (h *Hand) IsBalanced() bool {
 	}
 	return doubletonCount <= 1
 }
+
+// Contains returns true if the hand holds the card.
+func (h *Hand) Contains(c Card) bool {
+	for _, card := range h.Cards {
+		if card == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove takes the card out of the hand and reports whether it was held.
+func (h *Hand) Remove(c Card) bool {
+	for i, card := range h.Cards {
+		if card == c {
+			h.Cards = append(h.Cards[:i], h.Cards[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Suit returns the cards held in the specified suit, highest first.
+func (h *Hand) Suit(s Suit) []Card {
+	var cards []Card
+	for _, card := range h.Cards {
+		if card.Suit == s {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
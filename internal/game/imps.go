@@ -0,0 +1,80 @@
+package game
+
+// impThresholds holds the lowest score difference worth each IMP on the
+// WBF scale: 20-40 is 1 IMP, 50-80 is 2 IMPs, and so on up to 4000+ for 24.
+var impThresholds = []int{
+	20, 50, 90, 130, 170, 220, 270, 320, 370, 430, 500, 600,
+	750, 900, 1100, 1300, 1500, 1750, 2000, 2250, 2500, 3000, 3500, 4000,
+}
+
+// IMPs converts a score difference into International Match Points using
+// the WBF scale. The sign of the result follows the sign of diff.
+func IMPs(diff int) int {
+	sign := 1
+	if diff < 0 {
+		sign, diff = -1, -diff
+	}
+	imps := 0
+	for _, t := range impThresholds {
+		if diff < t {
+			break
+		}
+		imps++
+	}
+	return sign * imps
+}
+
+// SideScore returns a duplicate score from the point of view of the given
+// side: positive if the side gained the points, negative if it conceded them.
+func SideScore(c Contract, s Score, side Side) int {
+	if c.PassedOut {
+		return 0
+	}
+	if c.Declarer.Side() == side {
+		return s.TotalScore
+	}
+	return -s.TotalScore
+}
+
+// CompareIMPs returns the IMPs won (or lost, if negative) by a side whose
+// result on a board is ours, against the reference result at the other table.
+// Both scores must be from the same side's point of view, see SideScore.
+func CompareIMPs(ours, reference int) int {
+	return IMPs(ours - reference)
+}
+
+// Matchpoints scores each result on a board against all the others: one
+// matchpoint for every score beaten and half a matchpoint for every tie.
+// All scores must be from the same side's point of view.
+func Matchpoints(scores []int) []float64 {
+	mps := make([]float64, len(scores))
+	for i, s := range scores {
+		for j, other := range scores {
+			if i == j {
+				continue
+			}
+			switch {
+			case s > other:
+				mps[i]++
+			case s == other:
+				mps[i] += 0.5
+			}
+		}
+	}
+	return mps
+}
+
+// MatchpointPercentages returns each result's matchpoints as a percentage
+// of the top on the board. A board played only once scores 50%.
+func MatchpointPercentages(scores []int) []float64 {
+	mps := Matchpoints(scores)
+	top := float64(len(scores) - 1)
+	for i := range mps {
+		if top == 0 {
+			mps[i] = 50
+			continue
+		}
+		mps[i] = mps[i] / top * 100
+	}
+	return mps
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIMPs(t *testing.T) {
+	tests := []struct {
+		diff int
+		want int
+	}{
+		{0, 0}, {10, 0}, {20, 1}, {40, 1}, {50, 2}, {80, 2}, {90, 3}, {120, 3},
+		{130, 4}, {170, 5}, {210, 5}, {220, 6}, {270, 7}, {320, 8}, {370, 9},
+		{420, 9}, {430, 10}, {500, 11}, {590, 11}, {600, 12}, {740, 12}, {750, 13},
+		{900, 14}, {1100, 15}, {1300, 16}, {1500, 17}, {1750, 18}, {2000, 19},
+		{2250, 20}, {2500, 21}, {3000, 22}, {3500, 23}, {3990, 23}, {4000, 24},
+		{7600, 24}, {-420, -9}, {-620, -12},
+	}
+
+	for _, tt := range tests {
+		if got := IMPs(tt.diff); got != tt.want {
+			t.Errorf("IMPs(%d) = %d, want %d", tt.diff, got, tt.want)
+		}
+	}
+}
+
+func TestCompareIMPs(t *testing.T) {
+	// We made 4S vulnerable, the other table stopped in 3S making ten tricks.
+	full := Contract{Level: 4, Strain: Spades, Declarer: South}
+	part := Contract{Level: 3, Strain: Spades, Declarer: North}
+	ours := SideScore(full, ScoreContract(full, Vulnerable, 10), NorthSouth)
+	theirs := SideScore(part, ScoreContract(part, Vulnerable, 10), NorthSouth)
+	if got := CompareIMPs(ours, theirs); got != 10 {
+		t.Errorf("CompareIMPs(%d, %d) = %d, want 10", ours, theirs, got)
+	}
+
+	// Defending: East-West's 3NT went one down.
+	def := Contract{Level: 3, Strain: NoTrump, Declarer: East}
+	if got := SideScore(def, ScoreContract(def, NotVulnerable, 8), NorthSouth); got != 50 {
+		t.Errorf("SideScore() = %d, want 50", got)
+	}
+}
+
+func TestMatchpoints(t *testing.T) {
+	scores := []int{420, 450, 420, -50}
+	wantMPs := []float64{1.5, 3, 1.5, 0}
+	if got := Matchpoints(scores); !reflect.DeepEqual(got, wantMPs) {
+		t.Errorf("Matchpoints() = %v, want %v", got, wantMPs)
+	}
+	wantPct := []float64{50, 100, 50, 0}
+	if got := MatchpointPercentages(scores); !reflect.DeepEqual(got, wantPct) {
+		t.Errorf("MatchpointPercentages() = %v, want %v", got, wantPct)
+	}
+	if got := MatchpointPercentages([]int{100}); got[0] != 50 {
+		t.Errorf("MatchpointPercentages() single result = %v, want 50", got[0])
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+import "strings"
+
+// HandPicture is what the auction has shown about one seat's hand: the
+// ranges its HCP and suit lengths must lie in, narrowed by each of the
+// seat's explained calls.
+type HandPicture struct {
+	HCP      Range    // High card points
+	Lengths  [4]Range // Suit lengths, indexed by Suit
+	Balanced bool     // A call has shown a balanced hand
+}
+
+// unknownHand is the picture of a seat before it has shown anything.
+func unknownHand() HandPicture {
+	p := HandPicture{HCP: Range{0, 37}}
+	for s := range p.Lengths {
+		p.Lengths[s] = Range{0, 13}
+	}
+	return p
+}
+
+// narrow intersects r with shown. A call that contradicts what the seat
+// showed before is taken at its word, so shown replaces r.
+func narrow(r, shown Range) Range {
+	n := Range{max(r.Min, shown.Min), min(r.Max, shown.Max)}
+	if n.Min > n.Max {
+		return shown
+	}
+	return n
+}
+
+// show narrows the picture with what a call explains.
+func (p *HandPicture) show(e *Explanation) {
+	if e == nil {
+		return
+	}
+	if e.HCP != nil {
+		p.HCP = narrow(p.HCP, *e.HCP)
+	}
+	for s, l := range e.Lengths {
+		p.Lengths[s] = narrow(p.Lengths[s], l)
+	}
+	if e.Balanced {
+		// No singleton and at most one doubleton leaves 2 to 5 cards a suit.
+		p.Balanced = true
+		for s := range p.Lengths {
+			p.Lengths[s] = narrow(p.Lengths[s], Range{2, 5})
+		}
+	}
+	// A hand has thirteen cards, so what the other suits must hold
+	// limits each suit, and what they may hold at most sets its least.
+	for s := range p.Lengths {
+		least, most := 0, 0
+		for o, l := range p.Lengths {
+			if o != s {
+				least += l.Min
+				most += l.Max
+			}
+		}
+		p.Lengths[s] = narrow(p.Lengths[s], Range{13 - most, 13 - least})
+	}
+}
+
+// Shown reports whether the auction has limited the picture at all.
+func (p HandPicture) Shown() bool {
+	return p.String() != ""
+}
+
+// String describes the picture in a line, e.g. "15-17 HCP, 5+ hearts,
+// balanced", leaving out what is still unknown.
+func (p HandPicture) String() string {
+	var details []string
+	if p.HCP.Min > 0 || p.HCP.Max < 37 {
+		details = append(details, formatRange(p.HCP, 37)+" HCP")
+	}
+	for s := Spades; s >= Clubs; s-- {
+		if l := p.Lengths[s]; l.Min > 0 || l.Max < 13 {
+			details = append(details, formatRange(l, 13)+" "+suitNames[s])
+		}
+	}
+	if p.Balanced {
+		details = append(details, "balanced")
+	}
+	return strings.Join(details, ", ")
+}
+
+// infer brings the seats' pictures up to date with the bids made since
+// it last ran. Bids is exported, so an auction built or cut short
+// without AddBid is read again from the start.
+func (a *Auction) infer() {
+	if a.inferred == 0 || a.inferred > len(a.Bids) {
+		for i := range a.pictures {
+			a.pictures[i] = unknownHand()
+		}
+		a.inferred = 0
+	}
+	for ; a.inferred < len(a.Bids); a.inferred++ {
+		b := a.Bids[a.inferred]
+		if b.Position >= North && b.Position <= West {
+			a.pictures[b.Position].show(b.Explanation)
+		}
+	}
+}
+
+// Picture returns what the auction has shown about the hand at pos.
+func (a *Auction) Picture(pos Position) HandPicture {
+	a.infer()
+	return a.pictures[pos]
+}
+
+// PartnerPicture returns what partner has shown in the auction so far.
+func (p *Player) PartnerPicture(auction *Auction) HandPicture {
+	return auction.Picture(p.Position.Partner())
+}
@@ -0,0 +1,130 @@
+package game
+
+import "testing"
+
+func TestHandPicture_Show(t *testing.T) {
+	tests := []struct {
+		name  string
+		shown []Explanation
+		want  string
+	}{
+		{"nothing shown", nil, ""},
+		{"a call without limits", []Explanation{{Meaning: "To play"}}, ""},
+		{
+			"ranges narrow",
+			[]Explanation{
+				{HCP: &Range{11, 21}, Lengths: map[Suit]Range{Hearts: {5, 13}}},
+				{HCP: &Range{11, 14}, Lengths: map[Suit]Range{Hearts: {6, 13}}},
+			},
+			"11-14 HCP, 0-7 spades, 6+ hearts, 0-7 diamonds, 0-7 clubs",
+		},
+		{
+			"balanced limits every suit",
+			[]Explanation{{HCP: &Range{15, 17}, Balanced: true}},
+			"15-17 HCP, 2-5 spades, 2-5 hearts, 2-5 diamonds, 2-5 clubs, balanced",
+		},
+		{
+			"thirteen cards",
+			[]Explanation{{Lengths: map[Suit]Range{Spades: {6, 13}, Hearts: {5, 13}}}},
+			"6-8 spades, 5-7 hearts, 0-2 diamonds, 0-2 clubs",
+		},
+		{
+			"a contradiction takes the later call",
+			[]Explanation{{HCP: &Range{11, 14}}, {HCP: &Range{18, 19}}},
+			"18-19 HCP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := unknownHand()
+			for i := range tt.shown {
+				p.show(&tt.shown[i])
+			}
+			if got := p.String(); got != tt.want {
+				t.Errorf("picture = %q, want %q", got, tt.want)
+			}
+			if p.Shown() != (tt.want != "") {
+				t.Errorf("Shown() = %v for %q", p.Shown(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAuction_Picture(t *testing.T) {
+	a := NewAuction()
+	for _, hand := range []string{"AQ3.KJ4.K765.Q43", "432.432.432.5432", "Q2.KJ765.876.432"} {
+		p := systemPlayer(t, Position(len(a.Bids)), hand)
+		bid := p.MakeBid(a)
+		bid.Position = p.Position
+		a.AddBid(bid)
+	}
+	if got, want := a.Picture(North).String(), "15-17 HCP, 2-5 spades, 2-5 hearts, 2-5 diamonds, 2-5 clubs, balanced"; got != want {
+		t.Errorf("North after 1NT = %q, want %q", got, want)
+	}
+	if got, want := a.Picture(South).String(), "5+ HCP, 0-8 spades, 5+ hearts, 0-8 diamonds, 0-8 clubs"; got != want {
+		t.Errorf("South after the transfer = %q, want %q", got, want)
+	}
+	if a.Picture(West).Shown() {
+		t.Errorf("West has not called, but shows %q", a.Picture(West))
+	}
+	north := systemPlayer(t, North, "AQ3.KJ4.K765.Q43")
+	if got := north.PartnerPicture(a); got != a.Picture(South) {
+		t.Errorf("PartnerPicture = %q, want South's %q", got, a.Picture(South))
+	}
+
+	// An auction built from its bids, or cut short, is read again.
+	built := &Auction{Bids: a.Bids[:1]}
+	if got := built.Picture(North).HCP; got != (Range{15, 17}) {
+		t.Errorf("North in a built auction = %v, want 15-17", got)
+	}
+	a.Bids = a.Bids[:1]
+	if a.Picture(South).Shown() {
+		t.Errorf("South's call was taken back, but South shows %q", a.Picture(South))
+	}
+}
+
+func TestHandConstraint_Combined(t *testing.T) {
+	sys, err := ParseSystem([]byte(`{
+		"name": "Combined",
+		"rules": [
+			{"context": "opening", "hand": {"hcp": [15, 17], "balanced": true}, "call": "1NT"},
+			{"context": "response", "auction": "1NT", "hand": {"combined": [25, 37]}, "call": "3NT", "meaning": "Game values"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		hand    string
+		want    string
+		wantHCP Range
+	}{
+		{"game values", "Q43.543.AK65.K43", "3NT", Range{10, 22}},
+		{"one short", "Q43.543.AQ65.J43", "Pass", Range{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuction()
+			opener := systemPlayer(t, North, "AQ3.KJ4.K765.Q43")
+			opener.System = sys
+			for _, p := range []*Player{opener, systemPlayer(t, East, "432.432.432.5432")} {
+				bid := p.MakeBid(a)
+				bid.Position = p.Position
+				a.AddBid(bid)
+			}
+			p := systemPlayer(t, South, tt.hand)
+			p.System = sys
+			bid := p.MakeBid(a)
+			if bid.String() != tt.want {
+				t.Fatalf("call = %s, want %s", bid, tt.want)
+			}
+			if tt.want == "Pass" {
+				return
+			}
+			if bid.Explanation == nil || bid.Explanation.HCP == nil || *bid.Explanation.HCP != tt.wantHCP {
+				t.Errorf("explanation = %v, want %v HCP", bid.Explanation, tt.wantHCP)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// ErrPassedOut is returned when a deal set as a lead problem is passed out
+// and so has no opening lead.
+var ErrPassedOut = errors.New("the deal is passed out")
+
+// DefaultLeadLayouts is the number of simulated layouts leads are judged on
+// when no other number is given.
+const DefaultLeadLayouts = 10
+
+// LeadProblem is an opening lead quiz: a board bid to a contract by the
+// computer, seen from the seat of the opening leader.
+type LeadProblem struct {
+	Board    Board
+	Deal     Deal    // The actual layout; the leader sees only their own hand
+	Systems  Systems // The bidding systems the computer bid with
+	Auction  *Auction
+	Contract Contract
+}
+
+// NewLeadProblem has the computer bid all four hands of a deal on a board,
+// each partnership playing its system. It returns ErrPassedOut if nobody
+// opens.
+func NewLeadProblem(board Board, d Deal, systems Systems) (LeadProblem, error) {
+	auction := bidDeal(board, d, systems)
+	c, err := NewContract(auction)
+	if err != nil {
+		return LeadProblem{}, err
+	}
+	if c.PassedOut {
+		return LeadProblem{}, ErrPassedOut
+	}
+	return LeadProblem{Board: board, Deal: d, Systems: systems, Auction: auction, Contract: c}, nil
+}
+
+// RandomLeadProblem deals from r until a deal on the board is bid to a
+// contract.
+func RandomLeadProblem(board Board, systems Systems, r *rand.Rand) LeadProblem {
+	for {
+		if lp, err := NewLeadProblem(board, NewRandomDeal(r), systems); err == nil {
+			return lp
+		}
+	}
+}
+
+// bidDeal returns the auction the computer bids with the four hands of a
+// deal on a board, starting with the dealer.
+func bidDeal(board Board, d Deal, systems Systems) *Auction {
+	auction := NewAuction()
+	auction.Vulnerability = board.Vulnerability
+	return bidOut(d, auction, board.Dealer, systems)
+}
+
+// Leader returns the seat on opening lead.
+func (lp LeadProblem) Leader() Position {
+	return lp.Contract.OpeningLeader()
+}
+
+// Hand returns the opening leader's hand.
+func (lp LeadProblem) Hand() *Hand {
+	return lp.Deal.Hands[lp.Leader()]
+}
+
+// SampleLayouts deals the cards the leader cannot see at random, keeping up
+// to n layouts in which the computer would have bid the other three hands
+// exactly as they were bid. Fewer are returned when such layouts are rare.
+// It stops with the context's error once ctx is done.
+func (lp LeadProblem) SampleLayouts(ctx context.Context, n int, r *rand.Rand) ([]Deal, error) {
+	return sampleLayouts(ctx, lp.Leader(), lp.Hand(), lp.Auction, lp.Systems, n, r)
+}
+
+// LeadScore is how an opening lead fares over a set of layouts, played
+// double dummy after the lead.
+type LeadScore struct {
+	Card     Card
+	Tricks   float64 // Declarer's average tricks
+	Defeated float64 // Fraction of layouts in which the contract fails
+}
+
+// LeadAnalysis compares every card the leader may lead.
+type LeadAnalysis struct {
+	Layouts int         // Number of layouts the leads were judged on
+	Scores  []LeadScore // Best lead first: fewest tricks to declarer, then most often defeated
+}
+
+// AnalyzeLeads solves each layout double dummy after every possible
+// opening lead against the contract and averages the results. The layouts
+// are solved concurrently. Once ctx is done the solving stops, and the
+// context's error is returned.
+func AnalyzeLeads(ctx context.Context, c Contract, layouts []Deal) (LeadAnalysis, error) {
+	results := make([]map[Card]int, len(layouts))
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				p, _ := NewPlay(c, layouts[i])
+				results[i], _ = SolvePlay(ctx, p)
+			}
+		}()
+	}
+	for i := 0; i < len(layouts) && ctx.Err() == nil; i++ {
+		select {
+		case work <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(work)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return LeadAnalysis{}, err
+	}
+
+	a := LeadAnalysis{Layouts: len(layouts)}
+	if len(layouts) == 0 {
+		return a, nil
+	}
+	for _, card := range layouts[0].Hands[c.OpeningLeader()].Cards {
+		score := LeadScore{Card: card}
+		for _, r := range results {
+			score.Tricks += float64(r[card])
+			if r[card] < c.TricksRequired() {
+				score.Defeated++
+			}
+		}
+		score.Tricks /= float64(len(layouts))
+		score.Defeated /= float64(len(layouts))
+		a.Scores = append(a.Scores, score)
+	}
+	sort.SliceStable(a.Scores, func(i, j int) bool {
+		x, y := a.Scores[i], a.Scores[j]
+		if x.Tricks != y.Tricks {
+			return x.Tricks < y.Tricks
+		}
+		return x.Defeated > y.Defeated
+	})
+	return a, nil
+}
+
+// Score returns the score of a lead and its place in the ranking, from 1
+// for the best lead. Leads that score the same share a place.
+func (a LeadAnalysis) Score(c Card) (LeadScore, int, bool) {
+	for i, s := range a.Scores {
+		if s.Card == c {
+			rank := 1
+			for _, better := range a.Scores[:i] {
+				if better.Tricks != s.Tricks || better.Defeated != s.Defeated {
+					rank++
+				}
+			}
+			return s, rank, true
+		}
+	}
+	return LeadScore{}, 0, false
+}
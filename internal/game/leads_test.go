@@ -0,0 +1,122 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestNewLeadProblem(t *testing.T) {
+	d := testDeal(t)
+	lp, err := NewLeadProblem(NewBoard(1), d, Systems{})
+	if errors.Is(err, ErrPassedOut) {
+		t.Fatal("NewLeadProblem() passed out a deal with a 19-count in North")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lp.Auction.IsOver() {
+		t.Error("the problem's auction is not over")
+	}
+	if want, _ := NewContract(lp.Auction); lp.Contract != want {
+		t.Errorf("Contract = %s, want %s from the auction", lp.Contract, want)
+	}
+	if lp.Leader() != (lp.Contract.Declarer+1)%4 {
+		t.Errorf("Leader() = %s with %s declaring", lp.Leader(), lp.Contract.Declarer)
+	}
+	if lp.Hand() != d.Hands[lp.Leader()] {
+		t.Error("Hand() is not the leader's hand")
+	}
+}
+
+func TestSampleLayouts(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	lp := RandomLeadProblem(NewBoard(2), Systems{}, r)
+	layouts, err := lp.SampleLayouts(context.Background(), 5, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layouts) != 5 {
+		t.Fatalf("SampleLayouts(5) found %d layouts for %s", len(layouts), lp.Contract)
+	}
+	for i, d := range layouts {
+		if formatHolding(d.Hands[lp.Leader()]) != formatHolding(lp.Hand()) {
+			t.Errorf("layout %d changes the leader's hand", i)
+		}
+		seen := make(map[Card]bool)
+		for _, h := range d.Hands {
+			for _, c := range h.Cards {
+				seen[c] = true
+			}
+		}
+		if len(seen) != 52 {
+			t.Errorf("layout %d holds %d different cards", i, len(seen))
+		}
+		// The leader's calls depend only on their hand and the calls
+		// before, so the whole auction is bid again.
+		auction := bidDeal(lp.Board, d, lp.Systems)
+		if len(auction.Bids) != len(lp.Auction.Bids) {
+			t.Fatalf("layout %d is bid in %d calls, want %d", i, len(auction.Bids), len(lp.Auction.Bids))
+		}
+		for j, b := range auction.Bids {
+			if !sameCall(b, lp.Auction.Bids[j]) {
+				t.Errorf("layout %d: call %d is %s, want %s", i, j, b, lp.Auction.Bids[j])
+			}
+		}
+	}
+}
+
+func TestAnalyzeLeads(t *testing.T) {
+	d := testDeal(t)
+	c := Contract{Level: 3, Strain: NoTrump, Declarer: South}
+	p, _ := NewPlay(c, d)
+	want, err := SolvePlay(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := AnalyzeLeads(context.Background(), c, []Deal{d})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Layouts != 1 || len(a.Scores) != 13 {
+		t.Fatalf("AnalyzeLeads() scored %d leads on %d layouts, want 13 on 1", len(a.Scores), a.Layouts)
+	}
+	for i, s := range a.Scores {
+		if s.Tricks != float64(want[s.Card]) {
+			t.Errorf("lead %s: %.1f tricks, SolvePlay() gives %d", s.Card, s.Tricks, want[s.Card])
+		}
+		if defeated := want[s.Card] < 9; (s.Defeated == 1) != defeated {
+			t.Errorf("lead %s: defeated %.0f%% of the time with %d tricks", s.Card, 100*s.Defeated, want[s.Card])
+		}
+		if i > 0 && s.Tricks < a.Scores[i-1].Tricks {
+			t.Errorf("lead %s, scoring %.1f tricks, ranked below %s with %.1f", s.Card, s.Tricks, a.Scores[i-1].Card, a.Scores[i-1].Tricks)
+		}
+	}
+
+	best, rank, ok := a.Score(a.Scores[0].Card)
+	if !ok || rank != 1 || best != a.Scores[0] {
+		t.Errorf("Score(%s) = %v, %d, %v; want the best lead ranked 1", a.Scores[0].Card, best, rank, ok)
+	}
+	worst := a.Scores[12]
+	if _, rank, _ := a.Score(worst.Card); worst.Tricks > best.Tricks && rank == 1 {
+		t.Errorf("Score(%s) ranks a worse lead first", worst.Card)
+	}
+	if _, _, ok := a.Score(Card{Spades, Ace}); ok {
+		t.Error("Score() found a lead for a card West does not hold")
+	}
+}
+
+func TestLeads_Cancelled(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	lp := RandomLeadProblem(NewBoard(2), Systems{}, r)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := lp.SampleLayouts(ctx, 5, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("SampleLayouts() error = %v once cancelled, want context.Canceled", err)
+	}
+	if _, err := AnalyzeLeads(ctx, lp.Contract, []Deal{lp.Deal}); !errors.Is(err, context.Canceled) {
+		t.Errorf("AnalyzeLeads() error = %v once cancelled, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,365 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidLIN is returned when a LIN file or handviewer link cannot be parsed.
+var ErrInvalidLIN = errors.New("invalid LIN")
+
+// linSeats lists the seats in LIN order: md hands and dealer digits 1-4
+// start with South.
+var linSeats = [4]Position{South, West, North, East}
+
+// linSuits lists suit letters from the top, as used in md hands.
+var linSuits = [4]Suit{Spades, Hearts, Diamonds, Clubs}
+
+// linVulnerability maps BoardVulnerability to the sv value.
+var linVulnerability = map[BoardVulnerability]string{
+	VulNone:       "o",
+	VulNorthSouth: "n",
+	VulEastWest:   "e",
+	VulBoth:       "b",
+}
+
+// linCard writes a card as suit then rank, e.g. "SA" or "H7".
+func linCard(c Card) string {
+	return "CDHS"[c.Suit:c.Suit+1] + string(rankChar(c.Rank))
+}
+
+// linHand writes a hand as in an md record, e.g. "SAK3HQJTDC98765432".
+func linHand(h *Hand) string {
+	var b strings.Builder
+	for _, s := range linSuits {
+		b.WriteString("CDHS"[s : s+1])
+		for r := Ace; r >= Two; r-- {
+			for _, c := range h.Cards {
+				if c.Suit == s && c.Rank == r {
+					b.WriteByte(rankChar(r))
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// parseLINHand reads a hand from an md record. Suits may appear in any order.
+func parseLINHand(s string) ([]Card, error) {
+	var cards []Card
+	suit := -1
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch >= 'a' && ch <= 'z' {
+			ch -= 'a' - 'A'
+		}
+		if j := strings.IndexByte("CDHS", ch); j >= 0 {
+			suit = j
+			continue
+		}
+		r, ok := rankFromChar(ch)
+		if !ok || suit < 0 {
+			return nil, fmt.Errorf("invalid hand %q", s)
+		}
+		cards = append(cards, Card{Suit: Suit(suit), Rank: r})
+	}
+	return cards, nil
+}
+
+// linCall writes a call as in an mb record: 1C-7N, p, d or r.
+func linCall(b Bid) string {
+	switch {
+	case b.Pass:
+		return "p"
+	case b.Double:
+		return "d"
+	case b.Redouble:
+		return "r"
+	}
+	return strings.TrimSuffix(NewBid(b.Level, b.Strain).String(), "T")
+}
+
+// WriteLIN writes the records in BBO LIN format, one board per line. Each
+// line holds the hands (md), board name (ah), vulnerability (sv), the
+// auction with alerts and explanations (mb, an) and any cards played (pc).
+func WriteLIN(w io.Writer, records []BoardRecord) error {
+	for _, rec := range records {
+		if _, err := fmt.Fprintln(w, FormatLIN(rec)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormatLIN writes a single board as a LIN string.
+func FormatLIN(rec BoardRecord) string {
+	var b strings.Builder
+	put := func(key, value string) {
+		b.WriteString(key + "|" + value + "|")
+	}
+
+	hands := make([]string, 4)
+	dealer := 0
+	for i, p := range linSeats {
+		hands[i] = linHand(rec.Deal.Hands[p])
+		if p == rec.Board.Dealer {
+			dealer = i + 1
+		}
+	}
+	put("pn", "South,West,North,East")
+	put("st", "")
+	put("md", strconv.Itoa(dealer)+strings.Join(hands, ","))
+	put("rh", "")
+	put("ah", fmt.Sprintf("Board %d", rec.Board.Number))
+	put("sv", linVulnerability[rec.Board.Vulnerability])
+
+	if rec.Auction != nil {
+		for i, bid := range rec.Auction.Bids {
+			a := rec.Annotations[i]
+			call := linCall(bid)
+			if a.Alert {
+				call += "!"
+			}
+			put("mb", call)
+			if a.Note != "" {
+				put("an", a.Note)
+			}
+		}
+	}
+	for i, c := range rec.Play {
+		if i > 0 && i%4 == 0 {
+			put("pg", "")
+		}
+		put("pc", linCard(c))
+	}
+	if rec.Result >= 0 && rec.Contract != nil && !rec.Contract.PassedOut {
+		put("mc", strconv.Itoa(rec.Result))
+	}
+	return b.String()
+}
+
+// LINHandviewerURL returns a BBO handviewer link that shows the board.
+func LINHandviewerURL(rec BoardRecord) string {
+	return "https://www.bridgebase.com/tools/handviewer.html?lin=" + url.QueryEscape(FormatLIN(rec))
+}
+
+// ReadLIN reads every board in a LIN file. Each md record starts a new board.
+func ReadLIN(r io.Reader) ([]BoardRecord, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLIN(string(data))
+}
+
+// ParseLIN reads every board in a LIN string. A BBO handviewer link is also
+// accepted, in which case its lin parameter is parsed.
+func ParseLIN(s string) ([]BoardRecord, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLIN, err)
+		}
+		s = u.Query().Get("lin")
+		if s == "" {
+			return nil, fmt.Errorf("%w: link has no lin parameter", ErrInvalidLIN)
+		}
+	}
+
+	fields := strings.Split(s, "|")
+	var records []BoardRecord
+	var cur *linBoard
+	pendingNumber := 0
+
+	for i := 0; i+1 < len(fields); i += 2 {
+		key := strings.ToLower(strings.TrimSpace(fields[i]))
+		value := strings.TrimSpace(fields[i+1])
+
+		if key == "md" {
+			if cur != nil {
+				rec, err := cur.finish()
+				if err != nil {
+					return nil, err
+				}
+				records = append(records, rec)
+			}
+			cur = &linBoard{number: pendingNumber}
+			pendingNumber = 0
+			if err := cur.setHands(value); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if key == "qx" {
+			// Vugraph files name boards "o12"/"c12" (open/closed room) before the hands.
+			if n, err := strconv.Atoi(strings.TrimLeft(value, "ocOC")); err == nil {
+				pendingNumber = n
+			}
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		if err := cur.apply(key, value); err != nil {
+			return nil, err
+		}
+	}
+	if cur != nil {
+		rec, err := cur.finish()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// linBoard collects the records of one board while a LIN string is read.
+type linBoard struct {
+	number      int
+	dealer      Position
+	vul         BoardVulnerability
+	vulKnown    bool
+	deal        Deal
+	calls       []Bid
+	annotations map[int]Annotation
+	play        []Card
+	result      int
+	claimed     bool
+}
+
+// setHands reads an md record: the dealer digit followed by the South, West,
+// North and East hands. One hand may be left empty.
+func (lb *linBoard) setHands(value string) error {
+	if value == "" || value[0] < '1' || value[0] > '4' {
+		return fmt.Errorf("%w: md %q must start with the dealer 1-4", ErrInvalidLIN, value)
+	}
+	lb.dealer = linSeats[value[0]-'1']
+
+	parts := strings.Split(value[1:], ",")
+	var hands [4][]Card
+	var known [4]bool
+	for i, p := range linSeats {
+		if i >= len(parts) || strings.TrimSpace(parts[i]) == "" {
+			continue
+		}
+		cards, err := parseLINHand(strings.TrimSpace(parts[i]))
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidLIN, err)
+		}
+		hands[p], known[p] = cards, true
+	}
+	d, err := completeDeal(hands, known)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLIN, err)
+	}
+	lb.deal = d
+	return nil
+}
+
+// apply handles one record of the current board. Unknown records are ignored.
+func (lb *linBoard) apply(key, value string) error {
+	switch key {
+	case "ah":
+		if n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(value, "Board"))); err == nil {
+			lb.number = n
+		}
+	case "sv":
+		switch strings.ToLower(value) {
+		case "o", "0", "-", "":
+			lb.vul = VulNone
+		case "n":
+			lb.vul = VulNorthSouth
+		case "e":
+			lb.vul = VulEastWest
+		case "b":
+			lb.vul = VulBoth
+		default:
+			return fmt.Errorf("%w: vulnerability %q", ErrInvalidLIN, value)
+		}
+		lb.vulKnown = true
+	case "mb":
+		alert := strings.HasSuffix(value, "!")
+		b, err := parseCall(strings.TrimSuffix(value, "!"))
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidLIN, err)
+		}
+		lb.calls = append(lb.calls, b)
+		if alert {
+			lb.annotate(func(a *Annotation) { a.Alert = true })
+		}
+	case "an":
+		lb.annotate(func(a *Annotation) {
+			a.Alert = true
+			a.Note = value
+		})
+	case "pc":
+		c, err := ParseCard(value)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidLIN, err)
+		}
+		lb.play = append(lb.play, c)
+	case "mc":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > 13 {
+			return fmt.Errorf("%w: claim %q", ErrInvalidLIN, value)
+		}
+		lb.result, lb.claimed = n, true
+	}
+	return nil
+}
+
+// annotate changes the annotation of the last call.
+func (lb *linBoard) annotate(f func(*Annotation)) {
+	i := len(lb.calls) - 1
+	if i < 0 {
+		return
+	}
+	if lb.annotations == nil {
+		lb.annotations = map[int]Annotation{}
+	}
+	a := lb.annotations[i]
+	f(&a)
+	lb.annotations[i] = a
+}
+
+// finish builds the BoardRecord. Board numbers default to 1; the dealer
+// from md and an explicit sv override the board's standard rotation.
+func (lb *linBoard) finish() (BoardRecord, error) {
+	number := lb.number
+	if number < 1 {
+		number = 1
+	}
+	board := NewBoard(number)
+	board.Dealer = lb.dealer
+	if lb.vulKnown {
+		board.Vulnerability = lb.vul
+	}
+
+	rec := BoardRecord{Board: board, Deal: lb.deal, Annotations: lb.annotations, Play: lb.play, Result: -1}
+	if len(lb.calls) > 0 {
+		auction := NewAuction()
+		pos := board.Dealer
+		for _, b := range lb.calls {
+			if err := auction.ValidateBid(b); err != nil {
+				return rec, fmt.Errorf("%w: board %d: %v", ErrInvalidLIN, number, err)
+			}
+			b.Position = pos
+			auction.AddBid(b)
+			pos = (pos + 1) % 4
+		}
+		rec.Auction = auction
+		if c, err := NewContract(auction); err == nil {
+			rec.Contract = &c
+		}
+	}
+	if lb.claimed {
+		rec.Result = lb.result
+	}
+	return rec, nil
+}
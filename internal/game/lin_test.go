@@ -0,0 +1,103 @@
+package game
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleLIN = `pn|Ann,Bob,Cid,Dan|st||md|3ST43HAT2DAJT9CQJ3,S765H854D854CT982,SAKQ2HKJ3DQ76CA54,|rh||ah|Board 3|sv|e|` +
+	`mb|p|mb|p|mb|2N|mb|p|mb|3C!|an|Puppet Stayman|mb|p|mb|3D|mb|p|mb|3N|mb|p|mb|p|mb|p|` +
+	`pc|S7|pc|S2|pc|SJ|pc|S3|pg||pc|D2|mc|10|`
+
+func TestParseLIN(t *testing.T) {
+	recs, err := ParseLIN(sampleLIN)
+	if err != nil {
+		t.Fatalf("ParseLIN() error = %v", err)
+	}
+	if len(recs) != 1 {
+		t.Fatalf("ParseLIN() returned %d boards, want 1", len(recs))
+	}
+	r := recs[0]
+	if r.Board.Number != 3 || r.Board.Dealer != North || r.Board.Vulnerability != VulEastWest {
+		t.Errorf("board = %+v", r.Board)
+	}
+	if got := formatHolding(r.Deal.Hands[East]); got != "J98.Q976.K32.K76" {
+		t.Errorf("missing East hand filled with %s", got)
+	}
+	if r.Contract == nil || r.Contract.String() != "3NT by South" {
+		t.Errorf("contract = %v, want 3NT by South", r.Contract)
+	}
+	if a := r.Annotations[4]; !a.Alert || a.Note != "Puppet Stayman" {
+		t.Errorf("3C annotation = %+v", a)
+	}
+	want := []Card{{Spades, Seven}, {Spades, Two}, {Spades, Jack}, {Spades, Three}, {Diamonds, Two}}
+	if !reflect.DeepEqual(r.Play, want) {
+		t.Errorf("play = %v, want %v", r.Play, want)
+	}
+	if r.Result != 10 {
+		t.Errorf("result = %d, want 10", r.Result)
+	}
+}
+
+func TestParseLIN_HandviewerLink(t *testing.T) {
+	link := "https://www.bridgebase.com/tools/handviewer.html?lin=" + url.QueryEscape(sampleLIN)
+	recs, err := ParseLIN(link)
+	if err != nil {
+		t.Fatalf("ParseLIN(link) error = %v", err)
+	}
+	if len(recs) != 1 || recs[0].Board.Number != 3 {
+		t.Errorf("ParseLIN(link) = %+v", recs)
+	}
+}
+
+func TestLIN_RoundTrip(t *testing.T) {
+	var recs []BoardRecord
+	for n := 1; n <= 4; n++ {
+		board := NewBoard(n)
+		auction := NewAuction()
+		pos := board.Dealer
+		for _, b := range []Bid{NewBid(1, NoTrump), NewDouble(), NewBid(2, Hearts), NewPass(), NewPass(), NewPass()} {
+			b.Position = pos
+			auction.AddBid(b)
+			pos = (pos + 1) % 4
+		}
+		rec := NewBoardRecord(board, DealFromSeed(int64(n)), auction)
+		rec.Annotations = map[int]Annotation{2: {Alert: true, Note: "transfer"}}
+		rec.Play = []Card{{Clubs, Ace}}
+		rec.Result = 8
+		recs = append(recs, rec)
+	}
+
+	var b strings.Builder
+	if err := WriteLIN(&b, recs); err != nil {
+		t.Fatalf("WriteLIN() error = %v", err)
+	}
+	if !strings.Contains(b.String(), "mb|2H!|an|transfer|") {
+		t.Errorf("output missing alerted 2H:\n%s", b.String())
+	}
+	got, err := ReadLIN(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("ReadLIN() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, recs) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, recs)
+	}
+}
+
+func TestParseLIN_Errors(t *testing.T) {
+	tests := []string{
+		"md|5SAKQ,,,|",
+		"md|1SAKQ2HKJ3DQ76CA54,SAKQ2HKJ3DQ76CA54,,|",
+		"md|3ST43HAT2DAJT9CQJ3,S765H854D854CT982,SAKQ2HKJ3DQ76CA54,|mb|1C|mb|r|",
+		"md|3ST43HAT2DAJT9CQJ3,S765H854D854CT982,SAKQ2HKJ3DQ76CA54,|pc|ZZ|",
+		"https://www.bridgebase.com/tools/handviewer.html?bbo=y",
+	}
+	for _, in := range tests {
+		if _, err := ParseLIN(in); !errors.Is(err, ErrInvalidLIN) {
+			t.Errorf("ParseLIN(%q) error = %v, want ErrInvalidLIN", in, err)
+		}
+	}
+}
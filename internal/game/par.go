@@ -0,0 +1,131 @@
+package game
+
+// Par is the result of a deal when both sides bid perfectly, knowing the
+// double-dummy trick table: each side keeps bidding while that beats
+// letting the opponents play, sacrificing when going down doubled costs
+// less than the opponents' contract.
+type Par struct {
+	Contract Contract // Doubled when it is a sacrifice; PassedOut if neither side should bid
+	Tricks   int      // Tricks declarer takes double dummy
+	Score    int      // From North-South's point of view
+}
+
+// CalculatePar returns the par result of a deal from its trick table. The
+// dealer's side has the first chance to bid. Of two contracts that score
+// the same, par is the lower.
+func CalculatePar(t TrickTable, dealer Position, vul BoardVulnerability) Par {
+	p := parSearch{table: t, vul: vul}
+	first := dealer.Side()
+	second := first.Opponents()
+
+	// If the dealer's side passes, the other side may open or pass it out.
+	passed, passedBid := 0, -1
+	for bid := 0; bid < numBids; bid++ {
+		if v := p.after(bid, second); p.makes(bid, second) && p.better(second, v, passed) {
+			passed, passedBid = v, bid
+		}
+	}
+	best, bid, side := passed, passedBid, second
+	for b := 0; b < numBids; b++ {
+		if v := p.after(b, first); p.makes(b, first) && p.better(first, v, best) {
+			best, bid, side = v, b, first
+		}
+	}
+	if bid < 0 {
+		return Par{Contract: Contract{PassedOut: true}}
+	}
+
+	// Follow the auction to the contract it stops in.
+	for {
+		next := p.choice[side][bid]
+		if next < 0 {
+			break
+		}
+		bid, side = next, side.Opponents()
+	}
+	c, tricks := p.contract(bid, side)
+	return Par{Contract: c, Tricks: tricks, Score: p.result(bid, side)}
+}
+
+// DoubleDummyScore returns the score, from North-South's point of view, of
+// a contract that takes the number of tricks given by the trick table.
+func DoubleDummyScore(c Contract, t TrickTable, vul BoardVulnerability) int {
+	if c.PassedOut {
+		return 0
+	}
+	tricks := t.Tricks(c.Strain, c.Declarer)
+	score := ScoreContract(c, vul.IsVulnerable(c.Declarer.Side()), tricks)
+	return SideScore(c, score, NorthSouth)
+}
+
+// numBids is the number of contract bids, 1C to 7NT.
+const numBids = 35
+
+// parSearch finds the par contract by looking ahead through every
+// competitive auction. Bids are numbered in order from 1C (0) to 7NT (34).
+type parSearch struct {
+	table   TrickTable
+	vul     BoardVulnerability
+	known   [2][numBids]bool
+	outcome [2][numBids]int // Once a side has made a bid, by side and bid
+	choice  [2][numBids]int // The opponents' best answer to that bid, or -1 to pass
+}
+
+// after returns the outcome, from North-South's point of view, once side
+// has made bid and the opponents answer it as well as they can: by passing
+// (and doubling if the contract fails) or by bidding higher. A bid that
+// fails is only made as a sacrifice over a contract that would make, and a
+// sacrifice is not left to play when outbidding it does as well.
+func (p *parSearch) after(bid int, side Side) int {
+	if p.known[side][bid] {
+		return p.outcome[side][bid]
+	}
+	opps := side.Opponents()
+	best, choice := p.result(bid, side), -1
+	makes := p.makes(bid, side)
+	for next := bid + 1; next < numBids; next++ {
+		if !makes && !p.makes(next, opps) {
+			continue
+		}
+		if v := p.after(next, opps); p.better(opps, v, best) || (!makes && v == best && choice < 0) {
+			best, choice = v, next
+		}
+	}
+	p.known[side][bid] = true
+	p.outcome[side][bid], p.choice[side][bid] = best, choice
+	return best
+}
+
+// better reports whether side prefers outcome a to outcome b, both from
+// North-South's point of view.
+func (p *parSearch) better(side Side, a, b int) bool {
+	if side == NorthSouth {
+		return a > b
+	}
+	return a < b
+}
+
+// contract returns the contract for a bid by side, played by whichever
+// partner takes more tricks, and doubled if it fails.
+func (p *parSearch) contract(bid int, side Side) (Contract, int) {
+	c := Contract{Level: bid/5 + 1, Strain: Suit(bid % 5), Declarer: Position(side)}
+	if partner := c.Declarer.Partner(); p.table.Tricks(c.Strain, partner) > p.table.Tricks(c.Strain, c.Declarer) {
+		c.Declarer = partner
+	}
+	tricks := p.table.Tricks(c.Strain, c.Declarer)
+	c.Doubled = tricks < c.TricksRequired()
+	return c, tricks
+}
+
+// makes reports whether side makes bid.
+func (p *parSearch) makes(bid int, side Side) bool {
+	c, _ := p.contract(bid, side)
+	return !c.Doubled
+}
+
+// result returns the score, from North-South's point of view, of side
+// playing bid.
+func (p *parSearch) result(bid int, side Side) int {
+	c, _ := p.contract(bid, side)
+	return DoubleDummyScore(c, p.table, p.vul)
+}
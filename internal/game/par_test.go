@@ -0,0 +1,62 @@
+package game
+
+import "testing"
+
+// sideTable builds a trick table in which North and South take the given
+// number of tricks in each strain, and East-West take the rest.
+func sideTable(ns [5]int) TrickTable {
+	var t TrickTable
+	for strain, tricks := range ns {
+		t[strain] = [4]int{tricks, 13 - tricks, tricks, 13 - tricks}
+	}
+	return t
+}
+
+func TestCalculatePar(t *testing.T) {
+	tests := []struct {
+		name  string
+		table TrickTable
+		vul   BoardVulnerability
+		want  string
+		score int
+	}{
+		{"game", sideTable([5]int{7, 7, 7, 10, 7}), VulNone, "4S by North", 420},
+		{"vulnerable game", sideTable([5]int{7, 7, 7, 10, 7}), VulNorthSouth, "4S by North", 620},
+		{"cheap sacrifice", sideTable([5]int{7, 7, 4, 10, 7}), VulNone, "5HX by East", 300},
+		{"sacrifice at the level needed", sideTable([5]int{10, 7, 3, 10, 7}), VulNone, "5HX by East", 100},
+		{"sacrifice costs too much", sideTable([5]int{7, 7, 5, 10, 7}), VulEastWest, "4S by North", 420},
+		{"part-score", sideTable([5]int{6, 6, 6, 6, 5}), VulBoth, "1NT by East", -120},
+		{"slam", sideTable([5]int{7, 7, 12, 7, 11}), VulNone, "6H by North", 980},
+	}
+	for _, tt := range tests {
+		par := CalculatePar(tt.table, North, tt.vul)
+		if got := par.Contract.String(); got != tt.want || par.Score != tt.score {
+			t.Errorf("%s: par = %s, %d, want %s, %d", tt.name, got, par.Score, tt.want, tt.score)
+		}
+	}
+
+	// The partner who takes more tricks declares.
+	table := sideTable([5]int{7, 7, 7, 10, 7})
+	table[Spades][North] = 9
+	if par := CalculatePar(table, North, VulNone); par.Contract.Declarer != South || par.Tricks != 10 {
+		t.Errorf("par = %s taking %d tricks, want South to declare and take 10", par.Contract, par.Tricks)
+	}
+}
+
+func TestDoubleDummyScore(t *testing.T) {
+	table := sideTable([5]int{7, 7, 7, 10, 7})
+	tests := []struct {
+		contract Contract
+		want     int
+	}{
+		{Contract{Level: 3, Strain: Spades, Declarer: South}, 170},
+		{Contract{Level: 5, Strain: Spades, Declarer: North, Doubled: true}, -100},
+		{Contract{Level: 2, Strain: Clubs, Declarer: West}, 100},
+		{Contract{PassedOut: true}, 0},
+	}
+	for _, tt := range tests {
+		if got := DoubleDummyScore(tt.contract, table, VulNone); got != tt.want {
+			t.Errorf("DoubleDummyScore(%s) = %d, want %d", tt.contract, got, tt.want)
+		}
+	}
+}
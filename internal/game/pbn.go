@@ -0,0 +1,615 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPBN is returned when a PBN file cannot be parsed.
+var ErrInvalidPBN = errors.New("invalid PBN")
+
+// Annotation is an alert or explanatory note attached to a call.
+type Annotation struct {
+	Alert bool
+	Note  string
+}
+
+// BoardRecord is a board together with what is known about how it was bid
+// and played. It is the unit read and written by the PBN and LIN formats.
+type BoardRecord struct {
+	Event       string
+	Board       Board
+	Deal        Deal
+	Auction     *Auction           // Nil if the auction was not recorded
+	Annotations map[int]Annotation // Alerts and notes, keyed by index into Auction.Bids
+	Contract    *Contract          // Nil if not recorded
+	Play        []Card             // Cards played, in order, if recorded
+	Result      int                // Tricks taken by declarer, or -1 if unknown
+}
+
+// NewBoardRecord describes a board and its auction so far. The contract is
+// filled in once the auction is over; the result is left unknown.
+func NewBoardRecord(board Board, deal Deal, auction *Auction) BoardRecord {
+	rec := BoardRecord{Board: board, Deal: deal, Auction: auction, Result: -1}
+	if auction != nil {
+		if c, err := NewContract(auction); err == nil {
+			rec.Contract = &c
+		}
+	}
+	return rec
+}
+
+// pbnRanks lists rank characters from the ace down, as used in PBN and LIN.
+const pbnRanks = "AKQJT98765432"
+
+// rankFromChar converts a rank character (A, K, Q, J, T, 9-2) into a Rank.
+func rankFromChar(ch byte) (Rank, bool) {
+	i := strings.IndexByte(pbnRanks, ch)
+	if i < 0 {
+		return 0, false
+	}
+	return Ace - Rank(i), true
+}
+
+// rankChar returns the single-character rank used in PBN and LIN.
+func rankChar(r Rank) byte {
+	return pbnRanks[Ace-r]
+}
+
+// positionLetter returns N, E, S or W.
+func positionLetter(p Position) string {
+	return p.String()[:1]
+}
+
+// positionFromLetter reads N, E, S or W.
+func positionFromLetter(s string) (Position, bool) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "N":
+		return North, true
+	case "E":
+		return East, true
+	case "S":
+		return South, true
+	case "W":
+		return West, true
+	}
+	return 0, false
+}
+
+// formatHolding writes a hand as spades.hearts.diamonds.clubs, e.g. "AK3.QJT..98765432".
+func formatHolding(h *Hand) string {
+	suits := make([]string, 0, 4)
+	for s := Spades; s >= Clubs; s-- {
+		var b strings.Builder
+		for r := Ace; r >= Two; r-- {
+			for _, c := range h.Cards {
+				if c.Suit == s && c.Rank == r {
+					b.WriteByte(rankChar(r))
+				}
+			}
+		}
+		suits = append(suits, b.String())
+	}
+	return strings.Join(suits, ".")
+}
+
+// parseHolding reads a hand written as spades.hearts.diamonds.clubs.
+func parseHolding(s string) ([]Card, error) {
+	suits := strings.Split(s, ".")
+	if len(suits) != 4 {
+		return nil, fmt.Errorf("hand %q must have four suits", s)
+	}
+	var cards []Card
+	for i, holding := range suits {
+		suit := Spades - Suit(i)
+		holding = strings.ToUpper(strings.ReplaceAll(holding, "10", "T"))
+		for j := 0; j < len(holding); j++ {
+			r, ok := rankFromChar(holding[j])
+			if !ok {
+				return nil, fmt.Errorf("invalid card %q in hand %q", holding[j], s)
+			}
+			cards = append(cards, Card{Suit: suit, Rank: r})
+		}
+	}
+	return cards, nil
+}
+
+// completeDeal builds a Deal from the hands that were given, filling in a
+// single missing hand with the remaining cards. It checks that every card
+// is dealt exactly once.
+func completeDeal(hands [4][]Card, known [4]bool) (Deal, error) {
+	seen := make(map[Card]bool, 52)
+	missing := -1
+	for p := range hands {
+		if !known[p] {
+			if missing >= 0 {
+				return Deal{}, errors.New("more than one hand is missing")
+			}
+			missing = p
+			continue
+		}
+		if len(hands[p]) != 13 {
+			return Deal{}, fmt.Errorf("%s has %d cards", Position(p), len(hands[p]))
+		}
+		for _, c := range hands[p] {
+			if seen[c] {
+				return Deal{}, fmt.Errorf("card %s appears twice", c)
+			}
+			seen[c] = true
+		}
+	}
+	if missing >= 0 {
+		for _, c := range NewDeck() {
+			if !seen[c] {
+				hands[missing] = append(hands[missing], c)
+			}
+		}
+	}
+	var d Deal
+	for p := range d.Hands {
+		d.Hands[p] = NewHand(hands[p])
+	}
+	return d, nil
+}
+
+// pbnVulnerability converts between BoardVulnerability and the PBN tag value.
+var pbnVulnerability = map[BoardVulnerability]string{
+	VulNone:       "None",
+	VulNorthSouth: "NS",
+	VulEastWest:   "EW",
+	VulBoth:       "All",
+}
+
+// parsePBNVulnerability reads a [Vulnerable] tag value.
+func parsePBNVulnerability(s string) (BoardVulnerability, bool) {
+	switch strings.ToLower(s) {
+	case "none", "love", "-":
+		return VulNone, true
+	case "ns":
+		return VulNorthSouth, true
+	case "ew":
+		return VulEastWest, true
+	case "all", "both":
+		return VulBoth, true
+	}
+	return 0, false
+}
+
+// callString writes a call in PBN/LIN notation: 1C-7NT, Pass, X or XX.
+func callString(b Bid) string {
+	switch {
+	case b.Pass:
+		return "Pass"
+	case b.Double:
+		return "X"
+	case b.Redouble:
+		return "XX"
+	}
+	return NewBid(b.Level, b.Strain).String()
+}
+
+// parseCall reads a call in PBN or LIN notation.
+func parseCall(s string) (Bid, error) {
+	switch strings.ToUpper(s) {
+	case "PASS", "P":
+		return NewPass(), nil
+	case "X", "D", "DBL":
+		return NewDouble(), nil
+	case "XX", "R", "RDBL":
+		return NewRedouble(), nil
+	}
+	if len(s) < 2 || s[0] < '1' || s[0] > '7' {
+		return Bid{}, fmt.Errorf("invalid call %q", s)
+	}
+	var strain Suit
+	switch strings.ToUpper(s[1:]) {
+	case "C":
+		strain = Clubs
+	case "D":
+		strain = Diamonds
+	case "H":
+		strain = Hearts
+	case "S":
+		strain = Spades
+	case "N", "NT":
+		strain = NoTrump
+	default:
+		return Bid{}, fmt.Errorf("invalid call %q", s)
+	}
+	return NewBid(int(s[0]-'0'), strain), nil
+}
+
+// contractString writes a contract as in the PBN [Contract] tag, e.g. "4SX".
+func contractString(c Contract) string {
+	if c.PassedOut {
+		return "Pass"
+	}
+	s := NewBid(c.Level, c.Strain).String()
+	switch {
+	case c.Redoubled:
+		s += "XX"
+	case c.Doubled:
+		s += "X"
+	}
+	return s
+}
+
+// parseContractString reads a contract such as "4SX" or "Pass".
+func parseContractString(s string) (Contract, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "PASS" {
+		return Contract{PassedOut: true}, nil
+	}
+	var c Contract
+	switch {
+	case strings.HasSuffix(s, "XX"):
+		c.Redoubled = true
+		s = strings.TrimSuffix(s, "XX")
+	case strings.HasSuffix(s, "X"):
+		c.Doubled = true
+		s = strings.TrimSuffix(s, "X")
+	}
+	b, err := parseCall(s)
+	if err != nil || b.Pass || b.Double || b.Redouble {
+		return Contract{}, fmt.Errorf("invalid contract %q", s)
+	}
+	c.Level, c.Strain = b.Level, b.Strain
+	return c, nil
+}
+
+// WritePBN writes the records as PBN 2.1 games, with the mandatory tag set
+// plus the auction, alerts and notes when they are known.
+func WritePBN(w io.Writer, records []BoardRecord) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%% PBN 2.1\n%% EXPORT\n")
+	for _, rec := range records {
+		fmt.Fprintln(bw)
+		writePBNGame(bw, rec)
+	}
+	return bw.Flush()
+}
+
+// writePBNGame writes the tags of a single board.
+func writePBNGame(w io.Writer, rec BoardRecord) {
+	tag := func(name, value string) {
+		fmt.Fprintf(w, "[%s \"%s\"]\n", name, strings.ReplaceAll(value, `"`, `\"`))
+	}
+	tag("Event", rec.Event)
+	tag("Site", "")
+	tag("Date", time.Now().Format("2006.01.02"))
+	tag("Board", strconv.Itoa(rec.Board.Number))
+	tag("West", "")
+	tag("North", "")
+	tag("East", "")
+	tag("South", "")
+	tag("Dealer", positionLetter(rec.Board.Dealer))
+	tag("Vulnerable", pbnVulnerability[rec.Board.Vulnerability])
+	tag("Deal", FormatPBNDeal(rec.Deal, rec.Board.Dealer))
+	tag("Scoring", "")
+
+	switch {
+	case rec.Contract == nil:
+		tag("Declarer", "?")
+		tag("Contract", "?")
+	case rec.Contract.PassedOut:
+		tag("Declarer", "")
+		tag("Contract", "Pass")
+	default:
+		tag("Declarer", positionLetter(rec.Contract.Declarer))
+		tag("Contract", contractString(*rec.Contract))
+	}
+	if rec.Result >= 0 && rec.Contract != nil && !rec.Contract.PassedOut {
+		tag("Result", strconv.Itoa(rec.Result))
+	} else {
+		tag("Result", "")
+	}
+
+	if rec.Auction == nil || len(rec.Auction.Bids) == 0 {
+		return
+	}
+	tag("Auction", positionLetter(rec.Auction.Bids[0].Position))
+
+	var notes []string
+	for i, b := range rec.Auction.Bids {
+		call := callString(b)
+		if a, ok := rec.Annotations[i]; ok {
+			if a.Alert {
+				call += "!"
+			}
+			if a.Note != "" {
+				notes = append(notes, a.Note)
+				call += fmt.Sprintf(" =%d=", len(notes))
+			}
+		}
+		fmt.Fprint(w, call)
+		if i%4 == 3 || i == len(rec.Auction.Bids)-1 {
+			fmt.Fprintln(w)
+		} else {
+			fmt.Fprint(w, " ")
+		}
+	}
+	for i, n := range notes {
+		tag("Note", fmt.Sprintf("%d:%s", i+1, n))
+	}
+}
+
+// FormatPBNDeal writes a deal as in the PBN [Deal] tag, starting with the
+// given seat and going clockwise, e.g. "N:AKQ.JT9.8765.432 ...".
+func FormatPBNDeal(d Deal, first Position) string {
+	hands := make([]string, 4)
+	for i := range hands {
+		hands[i] = formatHolding(d.Hands[(first+Position(i))%4])
+	}
+	return positionLetter(first) + ":" + strings.Join(hands, " ")
+}
+
+// ParsePBNDeal reads a PBN [Deal] tag value. A single hand may be given as
+// "-", in which case it receives the remaining cards.
+func ParsePBNDeal(s string) (Deal, error) {
+	first, rest, found := strings.Cut(strings.TrimSpace(s), ":")
+	start, ok := positionFromLetter(first)
+	if !found || !ok {
+		return Deal{}, fmt.Errorf("%w: deal %q must start with a seat, e.g. N:", ErrInvalidPBN, s)
+	}
+	fields := strings.Fields(rest)
+	if len(fields) != 4 {
+		return Deal{}, fmt.Errorf("%w: deal %q must have four hands", ErrInvalidPBN, s)
+	}
+	var hands [4][]Card
+	var known [4]bool
+	for i, f := range fields {
+		p := (start + Position(i)) % 4
+		if f == "-" {
+			continue
+		}
+		cards, err := parseHolding(f)
+		if err != nil {
+			return Deal{}, fmt.Errorf("%w: %v", ErrInvalidPBN, err)
+		}
+		hands[p], known[p] = cards, true
+	}
+	d, err := completeDeal(hands, known)
+	if err != nil {
+		return Deal{}, fmt.Errorf("%w: %v", ErrInvalidPBN, err)
+	}
+	return d, nil
+}
+
+// pbnTag is a tag pair together with the section data that follows it.
+type pbnTag struct {
+	name, value string
+	data        []string
+}
+
+// ReadPBN reads every game in a PBN file. Games must contain at least a
+// [Deal] tag; the other supported tags are optional.
+func ReadPBN(r io.Reader) ([]BoardRecord, error) {
+	games, err := scanPBN(r)
+	if err != nil {
+		return nil, err
+	}
+	var records []BoardRecord
+	prev := map[string]string{}
+	for _, tags := range games {
+		rec, err := parsePBNGame(tags, prev)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// scanPBN splits a PBN file into games, each a list of tags. Games are
+// separated by blank lines; comments are dropped.
+func scanPBN(r io.Reader) ([][]pbnTag, error) {
+	var games [][]pbnTag
+	var cur []pbnTag
+	inComment := false
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if inComment {
+			if i := strings.Index(line, "}"); i >= 0 {
+				inComment = false
+				line = strings.TrimSpace(line[i+1:])
+			} else {
+				continue
+			}
+		}
+		if strings.HasPrefix(line, "%") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if i := strings.Index(line, "{"); i >= 0 {
+			if j := strings.Index(line[i:], "}"); j >= 0 {
+				line = strings.TrimSpace(line[:i] + line[i+j+1:])
+			} else {
+				inComment = true
+				line = strings.TrimSpace(line[:i])
+			}
+		}
+		if line == "" {
+			if len(cur) > 0 && !inComment {
+				games = append(games, cur)
+				cur = nil
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			end := strings.LastIndex(line, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated tag %q", ErrInvalidPBN, line)
+			}
+			name, value, _ := strings.Cut(strings.TrimSpace(line[1:end]), " ")
+			value = strings.TrimSpace(value)
+			value = strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+			value = strings.ReplaceAll(value, `\"`, `"`)
+			cur = append(cur, pbnTag{name: name, value: value})
+			continue
+		}
+		if len(cur) == 0 {
+			return nil, fmt.Errorf("%w: data %q before any tag", ErrInvalidPBN, line)
+		}
+		cur[len(cur)-1].data = append(cur[len(cur)-1].data, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(cur) > 0 {
+		games = append(games, cur)
+	}
+	return games, nil
+}
+
+// parsePBNGame builds a BoardRecord from the tags of one game. A tag value
+// of "#" repeats the value from the previous game.
+func parsePBNGame(tags []pbnTag, prev map[string]string) (BoardRecord, error) {
+	rec := BoardRecord{Result: -1, Board: NewBoard(1)}
+	byName := map[string]pbnTag{}
+	for _, t := range tags {
+		if t.value == "#" {
+			t.value = prev[t.name]
+		}
+		prev[t.name] = t.value
+		byName[t.name] = t
+	}
+
+	rec.Event = byName["Event"].value
+	if t, ok := byName["Board"]; ok && t.value != "" {
+		n, err := strconv.Atoi(t.value)
+		if err != nil || n < 1 {
+			return rec, fmt.Errorf("%w: board %q", ErrInvalidPBN, t.value)
+		}
+		rec.Board = NewBoard(n)
+	}
+	if t, ok := byName["Dealer"]; ok && t.value != "" {
+		p, ok := positionFromLetter(t.value)
+		if !ok {
+			return rec, fmt.Errorf("%w: dealer %q", ErrInvalidPBN, t.value)
+		}
+		rec.Board.Dealer = p
+	}
+	if t, ok := byName["Vulnerable"]; ok && t.value != "" {
+		v, ok := parsePBNVulnerability(t.value)
+		if !ok {
+			return rec, fmt.Errorf("%w: vulnerability %q", ErrInvalidPBN, t.value)
+		}
+		rec.Board.Vulnerability = v
+	}
+
+	t, ok := byName["Deal"]
+	if !ok {
+		return rec, fmt.Errorf("%w: board %d has no [Deal] tag", ErrInvalidPBN, rec.Board.Number)
+	}
+	deal, err := ParsePBNDeal(t.value)
+	if err != nil {
+		return rec, err
+	}
+	rec.Deal = deal
+
+	if t, ok := byName["Contract"]; ok && t.value != "" && t.value != "?" {
+		c, err := parseContractString(t.value)
+		if err != nil {
+			return rec, fmt.Errorf("%w: %v", ErrInvalidPBN, err)
+		}
+		if !c.PassedOut {
+			d, ok := positionFromLetter(byName["Declarer"].value)
+			if !ok {
+				return rec, fmt.Errorf("%w: declarer %q", ErrInvalidPBN, byName["Declarer"].value)
+			}
+			c.Declarer = d
+		}
+		rec.Contract = &c
+	}
+	if t, ok := byName["Result"]; ok && t.value != "" && t.value != "?" {
+		n, err := strconv.Atoi(t.value)
+		if err != nil || n < 0 || n > 13 {
+			return rec, fmt.Errorf("%w: result %q", ErrInvalidPBN, t.value)
+		}
+		rec.Result = n
+	}
+
+	if t, ok := byName["Auction"]; ok {
+		notes := map[string]string{}
+		for _, tag := range tags {
+			if tag.name == "Note" {
+				num, text, _ := strings.Cut(tag.value, ":")
+				notes[num] = text
+			}
+		}
+		if err := parsePBNAuction(&rec, t, notes); err != nil {
+			return rec, err
+		}
+	}
+	return rec, nil
+}
+
+// parsePBNAuction reads the [Auction] section, including alerts ("!"),
+// note references ("=1=") and "AP" for all pass.
+func parsePBNAuction(rec *BoardRecord, t pbnTag, notes map[string]string) error {
+	pos, ok := positionFromLetter(t.value)
+	if !ok {
+		return fmt.Errorf("%w: auction seat %q", ErrInvalidPBN, t.value)
+	}
+	auction := NewAuction()
+	rec.Annotations = map[int]Annotation{}
+	annotate := func(f func(*Annotation)) {
+		i := len(auction.Bids) - 1
+		if i < 0 {
+			return
+		}
+		a := rec.Annotations[i]
+		f(&a)
+		rec.Annotations[i] = a
+	}
+	add := func(b Bid) {
+		b.Position = pos
+		auction.AddBid(b)
+		pos = (pos + 1) % 4
+	}
+
+	for _, line := range t.data {
+		for _, tok := range strings.Fields(line) {
+			switch {
+			case strings.HasPrefix(tok, "=") && strings.HasSuffix(tok, "=") && len(tok) > 2:
+				text := notes[strings.Trim(tok, "=")]
+				annotate(func(a *Annotation) { a.Note = text })
+			case strings.HasPrefix(tok, "$"), tok == "-", tok == "*", tok == "+":
+				// Annotation glyphs, irregularities and unfinished-auction markers are ignored.
+			case strings.EqualFold(tok, "AP"):
+				for !auction.IsOver() {
+					add(NewPass())
+				}
+			default:
+				alert := strings.HasSuffix(tok, "!")
+				b, err := parseCall(strings.TrimRight(tok, "!?"))
+				if err != nil {
+					return fmt.Errorf("%w: %v", ErrInvalidPBN, err)
+				}
+				if err := auction.ValidateBid(b); err != nil {
+					return fmt.Errorf("%w: %v", ErrInvalidPBN, err)
+				}
+				add(b)
+				if alert {
+					annotate(func(a *Annotation) { a.Alert = true })
+				}
+			}
+		}
+	}
+	rec.Auction = auction
+	if len(rec.Annotations) == 0 {
+		rec.Annotations = nil
+	}
+	if rec.Contract == nil {
+		if c, err := NewContract(auction); err == nil {
+			rec.Contract = &c
+		}
+	}
+	return nil
+}
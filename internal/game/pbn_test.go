@@ -0,0 +1,136 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const samplePBN = `% PBN 2.1
+[Event "Club night"]
+[Board "3"]
+[Dealer "S"]
+[Vulnerable "EW"]
+[Deal "N:AKQ2.KJ3.Q76.A54 J98.Q976.K32.K76 T43.AT2.AJT9.QJ3 765.854.854.T982"]
+[Declarer "S"]
+[Contract "3NT"]
+[Result "9"]
+[Auction "S"]
+1NT Pass 2C =1= Pass
+2D! Pass 3NT AP
+[Note "1:Stayman"]
+
+[Event "#"]
+[Board "4"]
+[Dealer "W"]
+[Vulnerable "All"]
+[Deal "W:- AKQ2.KJ3.Q76.A54 J98.Q976.K32.K76 T43.AT2.AJT9.QJ3"]
+[Auction "W"]
+Pass Pass Pass Pass
+`
+
+func TestReadPBN(t *testing.T) {
+	recs, err := ReadPBN(strings.NewReader(samplePBN))
+	if err != nil {
+		t.Fatalf("ReadPBN() error = %v", err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("ReadPBN() returned %d boards, want 2", len(recs))
+	}
+
+	r := recs[0]
+	if r.Event != "Club night" || r.Board.Number != 3 || r.Board.Dealer != South || r.Board.Vulnerability != VulEastWest {
+		t.Errorf("board = %+v, event %q", r.Board, r.Event)
+	}
+	if got := formatHolding(r.Deal.Hands[South]); got != "T43.AT2.AJT9.QJ3" {
+		t.Errorf("South holds %s", got)
+	}
+	if r.Contract == nil || r.Contract.String() != "3NT by South" {
+		t.Errorf("contract = %v, want 3NT by South", r.Contract)
+	}
+	if r.Result != 9 {
+		t.Errorf("result = %d, want 9", r.Result)
+	}
+	if r.Auction == nil || len(r.Auction.Bids) != 10 || !r.Auction.IsAuctionComplete() {
+		t.Fatalf("auction = %v, want 10 calls ending in three passes", r.Auction)
+	}
+	if r.Auction.Bids[0].Position != South || r.Auction.Bids[1].Position != West {
+		t.Errorf("auction seats start %s, %s", r.Auction.Bids[0].Position, r.Auction.Bids[1].Position)
+	}
+	if a := r.Annotations[2]; a.Note != "Stayman" || a.Alert {
+		t.Errorf("2C annotation = %+v, want note Stayman", a)
+	}
+	if a := r.Annotations[4]; !a.Alert {
+		t.Errorf("2D annotation = %+v, want alert", a)
+	}
+
+	r = recs[1]
+	if r.Event != "Club night" {
+		t.Errorf("event = %q, want it inherited through #", r.Event)
+	}
+	if got := formatHolding(r.Deal.Hands[West]); got != "765.854.854.T982" {
+		t.Errorf("missing West hand filled with %s", got)
+	}
+	if r.Contract == nil || !r.Contract.PassedOut {
+		t.Errorf("contract = %v, want passed out", r.Contract)
+	}
+	if r.Result != -1 {
+		t.Errorf("result = %d, want unknown", r.Result)
+	}
+}
+
+func TestPBN_RoundTrip(t *testing.T) {
+	board := NewBoard(7)
+	deal := DealFromSeed(7)
+	auction := NewAuction()
+	pos := board.Dealer
+	for _, b := range []Bid{NewBid(1, Hearts), NewDouble(), NewRedouble(), NewBid(2, Clubs), NewBid(4, Hearts), NewPass(), NewPass(), NewDouble(), NewPass(), NewPass(), NewPass()} {
+		b.Position = pos
+		auction.AddBid(b)
+		pos = (pos + 1) % 4
+	}
+	rec := NewBoardRecord(board, deal, auction)
+	rec.Result = 8
+	rec.Annotations = map[int]Annotation{2: {Alert: true, Note: "10+ HCP"}}
+
+	var buf bytes.Buffer
+	if err := WritePBN(&buf, []BoardRecord{rec}); err != nil {
+		t.Fatalf("WritePBN() error = %v", err)
+	}
+	for _, want := range []string{`[Deal "S:`, `[Vulnerable "All"]`, `[Contract "4HX"]`, `[Result "8"]`, `XX! =1=`, `[Note "1:10+ HCP"]`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	recs, err := ReadPBN(&buf)
+	if err != nil {
+		t.Fatalf("ReadPBN() error = %v", err)
+	}
+	if len(recs) != 1 {
+		t.Fatalf("ReadPBN() returned %d boards, want 1", len(recs))
+	}
+	got := recs[0]
+	got.Event = rec.Event
+	if !reflect.DeepEqual(got, rec) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, rec)
+	}
+}
+
+func TestReadPBN_Errors(t *testing.T) {
+	tests := []string{
+		`[Board "1"]`,
+		`[Deal "N:AKQ.J.T.9 - - -"]`,
+		`[Deal "X:- - - -"]`,
+		`[Deal "N:AKQ2.KJ3.Q76.A54 AKQ2.KJ3.Q76.A54 T43.AT2.AJT9.QJ3 -"]`,
+		"[Deal \"N:AKQ2.KJ3.Q76.A54 J98.Q976.K32.K76 T43.AT2.AJT9.QJ3 -\"]\n[Auction \"N\"]\n1NT X X",
+		"[Deal \"N:AKQ2.KJ3.Q76.A54 J98.Q976.K32.K76 T43.AT2.AJT9.QJ3 -\"]\n[Vulnerable \"Some\"]",
+	}
+	for _, in := range tests {
+		if _, err := ReadPBN(strings.NewReader(in)); !errors.Is(err, ErrInvalidPBN) {
+			t.Errorf("ReadPBN(%q) error = %v, want ErrInvalidPBN", in, err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors describing why a card cannot be played.
+var (
+	ErrNoContract     = errors.New("the deal was passed out")
+	ErrPlayOver       = errors.New("all thirteen tricks have been played")
+	ErrNotYourTurn    = errors.New("not this seat's turn to play")
+	ErrCardNotHeld    = errors.New("card is not in this hand")
+	ErrMustFollowSuit = errors.New("must follow suit")
+)
+
+// IllegalPlayError reports a card that cannot be played, and why.
+type IllegalPlayError struct {
+	Position Position
+	Card     Card
+	Reason   error
+}
+
+func (e *IllegalPlayError) Error() string {
+	return fmt.Sprintf("%s cannot play %s: %v", e.Position, e.Card, e.Reason)
+}
+
+// Unwrap returns the underlying reason, so callers can use errors.Is.
+func (e *IllegalPlayError) Unwrap() error {
+	return e.Reason
+}
+
+// Trick is one round of four cards.
+type Trick struct {
+	Leader Position
+	Cards  []Card   // In playing order, starting with the leader
+	Winner Position // Set once the trick is complete
+}
+
+// Complete returns true once all four cards have been played.
+func (t *Trick) Complete() bool {
+	return len(t.Cards) == 4
+}
+
+// LedSuit returns the suit of the first card. The trick must not be empty.
+func (t *Trick) LedSuit() Suit {
+	return t.Cards[0].Suit
+}
+
+// PlayedBy returns the seat that played the i-th card of the trick.
+func (t *Trick) PlayedBy(i int) Position {
+	return (t.Leader + Position(i)) % 4
+}
+
+// winningIndex returns the index of the card currently winning the trick:
+// the highest trump, or the highest card of the led suit if no trump was played.
+func (t *Trick) winningIndex(trumps Suit) int {
+	best := 0
+	for i := 1; i < len(t.Cards); i++ {
+		c, w := t.Cards[i], t.Cards[best]
+		switch {
+		case c.Suit == w.Suit && c.Rank > w.Rank:
+			best = i
+		case c.Suit == trumps && w.Suit != trumps:
+			best = i
+		}
+	}
+	return best
+}
+
+// Play tracks the card play of a contract, trick by trick.
+type Play struct {
+	Contract Contract
+	Hands    [4]*Hand // Cards still held, by position
+	Tricks   []Trick  // Completed tricks, followed by the one in progress
+	Turn     Position // Seat that plays the next card
+	won      [2]int
+}
+
+// NewPlay starts the play of a contract. The hands are copied, so the deal
+// is left untouched; the opening leader is on lead.
+func NewPlay(c Contract, d Deal) (*Play, error) {
+	if c.PassedOut {
+		return nil, ErrNoContract
+	}
+	p := &Play{Contract: c, Turn: c.OpeningLeader()}
+	for i, h := range d.Hands {
+		p.Hands[i] = NewHand(h.Cards)
+	}
+	return p, nil
+}
+
+// Trumps returns the trump suit, or NoTrump.
+func (p *Play) Trumps() Suit {
+	return p.Contract.Strain
+}
+
+// CurrentTrick returns the trick in progress, or nil between tricks.
+func (p *Play) CurrentTrick() *Trick {
+	if n := len(p.Tricks); n > 0 && !p.Tricks[n-1].Complete() {
+		return &p.Tricks[n-1]
+	}
+	return nil
+}
+
+// LastTrick returns the most recently completed trick, or nil before the first one.
+func (p *Play) LastTrick() *Trick {
+	for i := len(p.Tricks) - 1; i >= 0; i-- {
+		if p.Tricks[i].Complete() {
+			return &p.Tricks[i]
+		}
+	}
+	return nil
+}
+
+// IsOver returns true once all thirteen tricks have been played.
+func (p *Play) IsOver() bool {
+	return len(p.Tricks) == 13 && p.Tricks[12].Complete()
+}
+
+// DummyVisible returns true once the opening lead has been made.
+func (p *Play) DummyVisible() bool {
+	return len(p.Tricks) > 0
+}
+
+// Controller returns the seat that chooses the cards for pos: declarer
+// plays dummy's cards as well as their own.
+func (p *Play) Controller(pos Position) Position {
+	if pos == p.Contract.Dummy() {
+		return p.Contract.Declarer
+	}
+	return pos
+}
+
+// TricksWon returns the number of tricks taken so far by a side.
+func (p *Play) TricksWon(side Side) int {
+	return p.won[side]
+}
+
+// DeclarerTricks returns the number of tricks taken so far by declarer's side.
+func (p *Play) DeclarerTricks() int {
+	return p.won[p.Contract.Declarer.Side()]
+}
+
+// Cards returns every card played so far, in order.
+func (p *Play) Cards() []Card {
+	var cards []Card
+	for _, t := range p.Tricks {
+		cards = append(cards, t.Cards...)
+	}
+	return cards
+}
+
+// LegalCards returns the cards the seat on turn may play: any card when
+// leading, otherwise a card of the led suit if they have one.
+func (p *Play) LegalCards() []Card {
+	if p.IsOver() {
+		return nil
+	}
+	hand := p.Hands[p.Turn]
+	if t := p.CurrentTrick(); t != nil {
+		if follow := hand.Suit(t.LedSuit()); len(follow) > 0 {
+			return follow
+		}
+	}
+	return append([]Card(nil), hand.Cards...)
+}
+
+// ValidatePlay checks whether pos may play the card now. It returns an
+// *IllegalPlayError describing the problem, or nil if the play is legal.
+func (p *Play) ValidatePlay(pos Position, c Card) error {
+	illegal := func(reason error) error {
+		return &IllegalPlayError{Position: pos, Card: c, Reason: reason}
+	}
+	switch {
+	case p.IsOver():
+		return illegal(ErrPlayOver)
+	case pos != p.Turn:
+		return illegal(ErrNotYourTurn)
+	case !p.Hands[pos].Contains(c):
+		return illegal(ErrCardNotHeld)
+	}
+	if t := p.CurrentTrick(); t != nil && c.Suit != t.LedSuit() && p.Hands[pos].SuitCount(t.LedSuit()) > 0 {
+		return illegal(ErrMustFollowSuit)
+	}
+	return nil
+}
+
+// PlayCard plays a card for pos. When the card completes a trick, the
+// winner is recorded and leads to the next one.
+func (p *Play) PlayCard(pos Position, c Card) error {
+	if err := p.ValidatePlay(pos, c); err != nil {
+		return err
+	}
+	p.Hands[pos].Remove(c)
+
+	t := p.CurrentTrick()
+	if t == nil {
+		p.Tricks = append(p.Tricks, Trick{Leader: pos})
+		t = &p.Tricks[len(p.Tricks)-1]
+	}
+	t.Cards = append(t.Cards, c)
+
+	if !t.Complete() {
+		p.Turn = (pos + 1) % 4
+		return nil
+	}
+	t.Winner = t.PlayedBy(t.winningIndex(p.Trumps()))
+	p.won[t.Winner.Side()]++
+	p.Turn = t.Winner
+	return nil
+}
+
+// Winning returns the seat currently winning the trick in progress.
+func (p *Play) Winning() (Position, bool) {
+	t := p.CurrentTrick()
+	if t == nil {
+		return 0, false
+	}
+	return t.PlayedBy(t.winningIndex(p.Trumps())), true
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func testDeal(t *testing.T) Deal {
+	t.Helper()
+	d, err := ParsePBNDeal("N:AKQ2.KJ3.Q76.A54 J98.Q976.K32.K76 T43.AT2.AJT9.QJ3 765.854.854.T982")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestNewPlay(t *testing.T) {
+	if _, err := NewPlay(Contract{PassedOut: true}, testDeal(t)); !errors.Is(err, ErrNoContract) {
+		t.Errorf("NewPlay(passed out) error = %v, want ErrNoContract", err)
+	}
+
+	d := testDeal(t)
+	p, err := NewPlay(Contract{Level: 4, Strain: Spades, Declarer: South}, d)
+	if err != nil {
+		t.Fatalf("NewPlay() error = %v", err)
+	}
+	if p.Turn != West {
+		t.Errorf("Turn = %s, want West on lead", p.Turn)
+	}
+	if p.DummyVisible() {
+		t.Error("dummy should not be visible before the opening lead")
+	}
+	if err := p.PlayCard(West, Card{Clubs, Ten}); err != nil {
+		t.Fatalf("opening lead error = %v", err)
+	}
+	if !p.DummyVisible() {
+		t.Error("dummy should be visible after the opening lead")
+	}
+	if len(d.Hands[West].Cards) != 13 {
+		t.Error("playing a card should not change the deal")
+	}
+	if p.Controller(North) != South || p.Controller(East) != East {
+		t.Error("declarer should control dummy, and only dummy")
+	}
+}
+
+func TestPlay_ValidatePlay(t *testing.T) {
+	p, _ := NewPlay(Contract{Level: 3, Strain: NoTrump, Declarer: South}, testDeal(t))
+	_ = p.PlayCard(West, Card{Spades, Seven})
+
+	tests := []struct {
+		name string
+		pos  Position
+		card Card
+		want error
+	}{
+		{"out of turn", East, Card{Spades, Jack}, ErrNotYourTurn},
+		{"card not held", North, Card{Spades, Jack}, ErrCardNotHeld},
+		{"revoke", North, Card{Hearts, King}, ErrMustFollowSuit},
+		{"follows suit", North, Card{Spades, Two}, nil},
+	}
+	for _, tt := range tests {
+		err := p.ValidatePlay(tt.pos, tt.card)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: ValidatePlay() = %v, want %v", tt.name, err, tt.want)
+		}
+		var ipe *IllegalPlayError
+		if tt.want != nil && !errors.As(err, &ipe) {
+			t.Errorf("%s: error %v is not an *IllegalPlayError", tt.name, err)
+		}
+	}
+
+	legal := p.LegalCards()
+	if len(legal) != 4 {
+		t.Errorf("LegalCards() = %v, want North's four spades", legal)
+	}
+}
+
+func TestPlay_TrickWinner(t *testing.T) {
+	tests := []struct {
+		name   string
+		trumps Suit
+		cards  []Card
+		want   Position
+	}{
+		{"highest of led suit", NoTrump, []Card{{Diamonds, Eight}, {Diamonds, Queen}, {Diamonds, King}, {Diamonds, Ace}}, South},
+		{"discard cannot win", NoTrump, []Card{{Hearts, Eight}, {Hearts, King}, {Spades, Ace}, {Hearts, Ten}}, North},
+		{"trump wins", Spades, []Card{{Hearts, Eight}, {Hearts, King}, {Spades, Two}, {Hearts, Ace}}, East},
+		{"overruff", Hearts, []Card{{Clubs, Ace}, {Hearts, Two}, {Clubs, Three}, {Hearts, Four}}, South},
+	}
+	for _, tt := range tests {
+		tr := Trick{Leader: West, Cards: tt.cards}
+		if got := tr.PlayedBy(tr.winningIndex(tt.trumps)); got != tt.want {
+			t.Errorf("%s: winner = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPlay_FullHand(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		d := DealFromSeed(seed)
+		c := Contract{Level: 2, Strain: Suit(seed % 5), Declarer: Position(seed % 4)}
+		p, err := NewPlay(c, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for !p.IsOver() {
+			card := ChooseCard(p)
+			if err := p.PlayCard(p.Turn, card); err != nil {
+				t.Fatalf("seed %d: ChooseCard() picked an illegal card: %v", seed, err)
+			}
+		}
+		if got := p.TricksWon(NorthSouth) + p.TricksWon(EastWest); got != 13 {
+			t.Errorf("seed %d: %d tricks won in total, want 13", seed, got)
+		}
+		if len(p.Cards()) != 52 {
+			t.Errorf("seed %d: %d cards played, want 52", seed, len(p.Cards()))
+		}
+		if err := p.PlayCard(p.Turn, Card{Spades, Ace}); !errors.Is(err, ErrPlayOver) {
+			t.Errorf("seed %d: playing after the last trick error = %v, want ErrPlayOver", seed, err)
+		}
+	}
+}
+
+func TestPlay_TricksPerSide(t *testing.T) {
+	p, _ := NewPlay(Contract{Level: 1, Strain: NoTrump, Declarer: South}, testDeal(t))
+	// West leads a spade, won by North's ace; North cashes the heart king.
+	for _, c := range []Card{{Spades, Seven}, {Spades, Ace}, {Spades, Eight}, {Spades, Three},
+		{Hearts, King}, {Hearts, Six}, {Hearts, Two}, {Hearts, Four}} {
+		if err := p.PlayCard(p.Turn, c); err != nil {
+			t.Fatalf("PlayCard(%s) error = %v", c, err)
+		}
+	}
+	if p.DeclarerTricks() != 2 || p.TricksWon(EastWest) != 0 {
+		t.Errorf("tricks = %d/%d, want 2/0", p.DeclarerTricks(), p.TricksWon(EastWest))
+	}
+	if p.Turn != North || p.LastTrick().Winner != North {
+		t.Errorf("Turn = %s, want North to lead after winning", p.Turn)
+	}
+}
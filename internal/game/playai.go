@@ -0,0 +1,88 @@
+package game
+
+// ChooseCard picks a card for the seat on turn using simple rules: lead
+// the top of a sequence or a low card from the longest suit, play low
+// second hand and when partner is winning, and otherwise win as cheaply as
+// possible. The play must not be over.
+func ChooseCard(p *Play) Card {
+	legal := p.LegalCards()
+	t := p.CurrentTrick()
+	if t == nil {
+		return chooseLead(p)
+	}
+
+	if winner, _ := p.Winning(); winner == p.Turn.Partner() {
+		return lowestCard(legal, p.Trumps())
+	}
+	if len(t.Cards) == 1 && legal[0].Suit == t.LedSuit() {
+		// Second hand low.
+		return lowestCard(legal, p.Trumps())
+	}
+	if c, ok := cheapestWinner(t, legal, p.Trumps()); ok {
+		return c
+	}
+	return lowestCard(legal, p.Trumps())
+}
+
+// chooseLead leads from the longest suit, preferring side suits: the top of
+// a sequence of honours, otherwise the lowest card.
+func chooseLead(p *Play) Card {
+	hand := p.Hands[p.Turn]
+	best := Suit(-1)
+	for s := Clubs; s <= Spades; s++ {
+		n := hand.SuitCount(s)
+		if n == 0 {
+			continue
+		}
+		switch {
+		case best < 0:
+			best = s
+		case s == p.Trumps() && best != p.Trumps():
+			// Keep the side suit unless trumps are all we hold.
+		case best == p.Trumps() || n >= hand.SuitCount(best):
+			best = s
+		}
+	}
+	cards := hand.Suit(best)
+	if len(cards) >= 2 && cards[0].Rank >= Jack && cards[0].Rank == cards[1].Rank+1 {
+		return cards[0]
+	}
+	return cards[len(cards)-1]
+}
+
+// cheapestWinner returns the lowest legal card that would win the trick so far.
+func cheapestWinner(t *Trick, legal []Card, trumps Suit) (Card, bool) {
+	var best Card
+	found := false
+	for _, c := range legal {
+		trial := Trick{Leader: t.Leader, Cards: append(append([]Card(nil), t.Cards...), c)}
+		if trial.winningIndex(trumps) != len(trial.Cards)-1 {
+			continue
+		}
+		if !found || cardCost(c, trumps) < cardCost(best, trumps) {
+			best, found = c, true
+		}
+	}
+	return best, found
+}
+
+// lowestCard returns the least valuable card, keeping trumps where possible.
+func lowestCard(cards []Card, trumps Suit) Card {
+	best := cards[0]
+	for _, c := range cards[1:] {
+		if cardCost(c, trumps) < cardCost(best, trumps) {
+			best = c
+		}
+	}
+	return best
+}
+
+// cardCost orders cards by how reluctant we are to part with them: trumps
+// cost more than any side-suit card, and higher ranks cost more.
+func cardCost(c Card, trumps Suit) int {
+	cost := int(c.Rank)
+	if c.Suit == trumps {
+		cost += 20
+	}
+	return cost
+}
@@ -0,0 +1,165 @@
+package game
+
+// Rubber bonuses for winning a rubber two games to nil or two games to one.
+const (
+	rubberBonusTwoNil = 700
+	rubberBonusTwoOne = 500
+)
+
+// RubberEntry is one line of a rubber bridge score sheet: the points each
+// side scored below and above the line on a single deal.
+type RubberEntry struct {
+	Contract  Contract
+	Tricks    int
+	BelowLine [2]int // Indexed by Side
+	AboveLine [2]int // Indexed by Side
+	GameWon   bool   // True if this deal completed a game
+}
+
+// Rubber keeps the score sheet of a rubber bridge match. Contract trick
+// points go below the line toward game; everything else goes above it.
+// The first side to win two games wins the rubber.
+type Rubber struct {
+	Entries   []RubberEntry
+	Games     [2]int // Games won by each side
+	PartScore [2]int // Below-the-line points toward the current game
+	BelowLine [2]int // Below-the-line points over the whole rubber
+	AboveLine [2]int // Above-the-line points over the whole rubber
+}
+
+// NewRubber starts a new rubber with both sides non-vulnerable.
+func NewRubber() *Rubber {
+	return &Rubber{}
+}
+
+// Vulnerable returns the side's vulnerability: a side that has won a game
+// is vulnerable for the rest of the rubber.
+func (r *Rubber) Vulnerable(side Side) Vulnerability {
+	return Vulnerability(r.Games[side] > 0)
+}
+
+// Vulnerability returns which sides are vulnerable on the next deal of
+// the rubber.
+func (r *Rubber) Vulnerability() BoardVulnerability {
+	switch ns, ew := bool(r.Vulnerable(NorthSouth)), bool(r.Vulnerable(EastWest)); {
+	case ns && ew:
+		return VulBoth
+	case ns:
+		return VulNorthSouth
+	case ew:
+		return VulEastWest
+	}
+	return VulNone
+}
+
+// IsComplete returns true once a side has won two games.
+func (r *Rubber) IsComplete() bool {
+	return r.Games[NorthSouth] == 2 || r.Games[EastWest] == 2
+}
+
+// Winner returns the side with the larger total once the rubber is complete.
+func (r *Rubber) Winner() (Side, bool) {
+	if !r.IsComplete() {
+		return 0, false
+	}
+	totals := r.Totals()
+	if totals[EastWest] > totals[NorthSouth] {
+		return EastWest, true
+	}
+	return NorthSouth, true
+}
+
+// Totals returns each side's total points, above and below the line.
+func (r *Rubber) Totals() [2]int {
+	return [2]int{
+		r.BelowLine[NorthSouth] + r.AboveLine[NorthSouth],
+		r.BelowLine[EastWest] + r.AboveLine[EastWest],
+	}
+}
+
+// Record scores a completed deal: the contract, the number of tricks the
+// declarer took and any honours held (see Honours). Passed-out deals score
+// nothing and are not recorded.
+func (r *Rubber) Record(c Contract, tricks int, honours Honour) RubberEntry {
+	entry := RubberEntry{Contract: c, Tricks: tricks}
+	if c.PassedOut {
+		return entry
+	}
+
+	declarer := c.Declarer.Side()
+	defenders := declarer.Opponents()
+	vul := r.Vulnerable(declarer)
+	score := ScoreContract(c, vul, tricks)
+
+	if score.Undertricks > 0 {
+		entry.AboveLine[defenders] += score.PenaltyScore
+	} else {
+		entry.BelowLine[declarer] += score.TrickScore
+		entry.AboveLine[declarer] += score.OvertrickScore + slamBonus(c, vul) + insultBonus(c)
+	}
+	entry.AboveLine[honours.Side] += honours.Points
+
+	for side := range entry.BelowLine {
+		r.BelowLine[side] += entry.BelowLine[side]
+		r.AboveLine[side] += entry.AboveLine[side]
+	}
+
+	r.PartScore[declarer] += entry.BelowLine[declarer]
+	if r.PartScore[declarer] >= 100 {
+		entry.GameWon = true
+		r.Games[declarer]++
+		r.PartScore = [2]int{}
+		if r.Games[declarer] == 2 {
+			bonus := rubberBonusTwoOne
+			if r.Games[defenders] == 0 {
+				bonus = rubberBonusTwoNil
+			}
+			entry.AboveLine[declarer] += bonus
+			r.AboveLine[declarer] += bonus
+		}
+	}
+
+	r.Entries = append(r.Entries, entry)
+	return entry
+}
+
+// Honour describes honours held in a single hand and the side credited.
+type Honour struct {
+	Side   Side
+	Points int
+}
+
+// Honours finds honours held in one hand: 100 for four of the five trump
+// honours, 150 for all five, or 150 for all four aces in a no-trump
+// contract. Honours score for the side holding them, whoever declares.
+// hands is indexed by Position.
+func Honours(c Contract, hands [4]*Hand) Honour {
+	if c.PassedOut {
+		return Honour{}
+	}
+	for pos, hand := range hands {
+		if hand == nil {
+			continue
+		}
+		count := 0
+		for _, card := range hand.Cards {
+			if c.Strain == NoTrump {
+				if card.Rank == Ace {
+					count++
+				}
+			} else if card.Suit == c.Strain && card.Rank >= Ten {
+				count++
+			}
+		}
+		side := Position(pos).Side()
+		switch {
+		case c.Strain == NoTrump && count == 4:
+			return Honour{Side: side, Points: 150}
+		case c.Strain != NoTrump && count == 5:
+			return Honour{Side: side, Points: 150}
+		case c.Strain != NoTrump && count == 4:
+			return Honour{Side: side, Points: 100}
+		}
+	}
+	return Honour{}
+}
@@ -0,0 +1,104 @@
+package game
+
+import "testing"
+
+func TestRubber_PartScoresAndGames(t *testing.T) {
+	r := NewRubber()
+
+	// N-S make 2S: 60 below the line, not yet a game.
+	e := r.Record(Contract{Level: 2, Strain: Spades, Declarer: South}, 8, Honour{})
+	if e.BelowLine[NorthSouth] != 60 || e.GameWon {
+		t.Fatalf("2S= entry = %+v, want 60 below and no game", e)
+	}
+
+	// N-S make 2H with an overtrick: 60 below completes the game, 30 above.
+	e = r.Record(Contract{Level: 2, Strain: Hearts, Declarer: North}, 9, Honour{})
+	if !e.GameWon || e.AboveLine[NorthSouth] != 30 {
+		t.Fatalf("2H+1 entry = %+v, want a game and 30 above", e)
+	}
+	if r.Vulnerable(NorthSouth) != Vulnerable || r.Vulnerable(EastWest) != NotVulnerable {
+		t.Fatalf("after one game N-S should be vulnerable and E-W not")
+	}
+	if v := r.Vulnerability(); v != VulNorthSouth {
+		t.Fatalf("vulnerability after one game = %s, want N-S", v)
+	}
+	if r.PartScore != [2]int{} {
+		t.Fatalf("part-scores should reset after a game, got %v", r.PartScore)
+	}
+
+	// N-S, now vulnerable, go two down doubled in 4S: 500 to E-W.
+	e = r.Record(Contract{Level: 4, Strain: Spades, Declarer: South, Doubled: true}, 8, Honour{})
+	if e.AboveLine[EastWest] != 500 {
+		t.Fatalf("4SX-2 vul entry = %+v, want 500 above for E-W", e)
+	}
+
+	// E-W bid and make 3NT: game for E-W, 1-1.
+	r.Record(Contract{Level: 3, Strain: NoTrump, Declarer: East}, 9, Honour{})
+	if r.Games != [2]int{1, 1} || r.IsComplete() {
+		t.Fatalf("games = %v, want 1-1 and the rubber in progress", r.Games)
+	}
+	if v := r.Vulnerability(); v != VulBoth {
+		t.Fatalf("vulnerability at game all = %s, want Both", v)
+	}
+
+	// N-S make 4H with 100 honours: rubber won 2-1.
+	e = r.Record(Contract{Level: 4, Strain: Hearts, Declarer: North}, 10, Honour{Side: NorthSouth, Points: 100})
+	if e.AboveLine[NorthSouth] != 100+rubberBonusTwoOne {
+		t.Fatalf("4H= entry = %+v, want honours plus the 500 rubber bonus", e)
+	}
+	if !r.IsComplete() {
+		t.Fatal("rubber should be complete")
+	}
+
+	// N-S: below 60+60+120 = 240, above 30+100+500 = 630.
+	// E-W: below 100, above 500.
+	if got := r.Totals(); got != [2]int{870, 600} {
+		t.Errorf("Totals() = %v, want [870 600]", got)
+	}
+	if side, ok := r.Winner(); !ok || side != NorthSouth {
+		t.Errorf("Winner() = %v, %v, want North-South", side, ok)
+	}
+}
+
+func TestRubber_TwoNilBonusAndSlam(t *testing.T) {
+	r := NewRubber()
+	r.Record(Contract{Level: 4, Strain: Spades, Declarer: West}, 10, Honour{})
+	e := r.Record(Contract{Level: 6, Strain: Diamonds, Declarer: East}, 12, Honour{})
+	// Vulnerable small slam 750 plus the 700 two-game bonus.
+	if e.AboveLine[EastWest] != 750+rubberBonusTwoNil {
+		t.Fatalf("6D= entry = %+v, want 1450 above", e)
+	}
+	if r.Vulnerable(NorthSouth) != NotVulnerable {
+		t.Error("N-S never won a game and should not be vulnerable")
+	}
+}
+
+func TestRubber_PassedOut(t *testing.T) {
+	r := NewRubber()
+	r.Record(Contract{PassedOut: true}, 0, Honour{})
+	if len(r.Entries) != 0 {
+		t.Errorf("passed-out deals should not be recorded, got %d entries", len(r.Entries))
+	}
+}
+
+func TestHonours(t *testing.T) {
+	var hands [4]*Hand
+	hands[East] = NewHand([]Card{
+		{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: Queen}, {Suit: Hearts, Rank: Ten},
+		{Suit: Spades, Rank: Ace}, {Suit: Clubs, Rank: Ace}, {Suit: Diamonds, Rank: Ace},
+	})
+	hands[North] = NewHand([]Card{{Suit: Hearts, Rank: Jack}})
+
+	got := Honours(Contract{Level: 4, Strain: Hearts, Declarer: North}, hands)
+	if got != (Honour{Side: EastWest, Points: 100}) {
+		t.Errorf("Honours() in hearts = %+v, want 100 for E-W", got)
+	}
+	got = Honours(Contract{Level: 3, Strain: NoTrump, Declarer: South}, hands)
+	if got != (Honour{Side: EastWest, Points: 150}) {
+		t.Errorf("Honours() in NT = %+v, want 150 for E-W", got)
+	}
+	got = Honours(Contract{Level: 2, Strain: Spades, Declarer: South}, hands)
+	if got != (Honour{}) {
+		t.Errorf("Honours() in spades = %+v, want none", got)
+	}
+}
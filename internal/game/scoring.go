@@ -8,75 +8,154 @@ const (
 	Vulnerable    Vulnerability = true
 )
 
-// Score represents the score for a contract.
+// Score represents the duplicate score of a played contract, from the
+// declarer's point of view. TotalScore is negative when the contract fails.
 type Score struct {
-	TrickScore int
-	BonusScore int
-	TotalScore int
-	MadeGame   bool
-	MadeSlam   bool
+	TrickScore     int // Points for contracted tricks (below the line)
+	OvertrickScore int // Points for tricks above the contract
+	BonusScore     int // Game or part-score, slam and insult bonuses
+	PenaltyScore   int // Points conceded for undertricks (a positive number)
+	TotalScore     int
+	Overtricks     int
+	Undertricks    int
+	MadeGame       bool
+	MadeSlam       bool
 }
 
-// CalculateScore calculates the score for a made contract.
-// This is a simplified version that assumes the contract is made exactly.
+// CalculateScore calculates the score for a contract made exactly. The
+// contract's Double and Redouble flags select the doubled state.
 func CalculateScore(contract Bid, vulnerability Vulnerability) Score {
+	c := Contract{
+		Level:     contract.Level,
+		Strain:    contract.Strain,
+		Doubled:   contract.Double && !contract.Redouble,
+		Redoubled: contract.Redouble,
+	}
+	return ScoreContract(c, vulnerability, c.TricksRequired())
+}
+
+// ScoreContract calculates the duplicate score for a contract, given the
+// declarer's vulnerability and the number of tricks declarer took.
+func ScoreContract(c Contract, vulnerability Vulnerability, tricks int) Score {
 	var score Score
-	tricks := contract.Level
+	if c.PassedOut {
+		return score
+	}
 
-	// Calculate trick score
-	switch contract.Strain {
-	case Clubs, Diamonds:
-		score.TrickScore = tricks * 20
-	case Hearts, Spades:
-		score.TrickScore = tricks * 30
-	case 4: // NoTrump
-		score.TrickScore = 40 + (tricks-1)*30
+	if tricks < c.TricksRequired() {
+		score.Undertricks = c.TricksRequired() - tricks
+		score.PenaltyScore = undertrickPenalty(c, vulnerability, score.Undertricks)
+		score.TotalScore = -score.PenaltyScore
+		return score
 	}
 
-	if contract.Double {
+	score.TrickScore = contractTrickScore(c.Strain, c.Level)
+	switch {
+	case c.Redoubled:
+		score.TrickScore *= 4
+	case c.Doubled:
 		score.TrickScore *= 2
 	}
-	if contract.Redouble {
-		score.TrickScore *= 4
+
+	// Overtricks are worth their trick value undoubled, and a flat amount
+	// per trick when doubled or redoubled.
+	score.Overtricks = tricks - c.TricksRequired()
+	switch {
+	case c.Redoubled:
+		score.OvertrickScore = score.Overtricks * vulnerableChoice(vulnerability, 200, 400)
+	case c.Doubled:
+		score.OvertrickScore = score.Overtricks * vulnerableChoice(vulnerability, 100, 200)
+	default:
+		score.OvertrickScore = contractTrickScore(c.Strain, c.Level+score.Overtricks) - contractTrickScore(c.Strain, c.Level)
 	}
 
-	// Calculate bonus score
+	// Game or part-score bonus
 	if score.TrickScore >= 100 {
 		score.MadeGame = true
-		if vulnerability {
-			score.BonusScore += 500
-		} else {
-			score.BonusScore += 300
-		}
+		score.BonusScore += vulnerableChoice(vulnerability, 300, 500)
 	} else {
 		score.BonusScore += 50 // Part-score bonus
 	}
 
-	// Slam bonus
-	if tricks == 6 { // Small slam
-		if vulnerability {
-			score.BonusScore += 750
-		} else {
-			score.BonusScore += 500
-		}
-		score.MadeSlam = true
-	} else if tricks == 7 { // Grand slam
-		if vulnerability {
-			score.BonusScore += 1500
-		} else {
-			score.BonusScore += 1000
-		}
-		score.MadeSlam = true
+	score.BonusScore += slamBonus(c, vulnerability) + insultBonus(c)
+	score.MadeSlam = c.Level >= 6
+
+	score.TotalScore = score.TrickScore + score.OvertrickScore + score.BonusScore
+	return score
+}
+
+// slamBonus returns the bonus for bidding and making a small or grand slam.
+func slamBonus(c Contract, vulnerability Vulnerability) int {
+	switch c.Level {
+	case 6: // Small slam
+		return vulnerableChoice(vulnerability, 500, 750)
+	case 7: // Grand slam
+		return vulnerableChoice(vulnerability, 1000, 1500)
 	}
+	return 0
+}
+
+// insultBonus returns the bonus for making a doubled ("insult") or redoubled contract.
+func insultBonus(c Contract) int {
+	switch {
+	case c.Redoubled:
+		return 100
+	case c.Doubled:
+		return 50
+	}
+	return 0
+}
 
-	// Doubled/Redoubled bonus for making the contract
-	if contract.Double {
-		score.BonusScore += 50
+// contractTrickScore returns the undoubled value of the given number of
+// tricks over book in a strain.
+func contractTrickScore(strain Suit, tricks int) int {
+	if tricks <= 0 {
+		return 0
 	}
-	if contract.Redouble {
-		score.BonusScore += 100
+	switch strain {
+	case Clubs, Diamonds:
+		return tricks * 20
+	case Hearts, Spades:
+		return tricks * 30
+	default: // NoTrump
+		return 40 + (tricks-1)*30
 	}
+}
 
-	score.TotalScore = score.TrickScore + score.BonusScore
-	return score
+// undertrickPenalty returns the points conceded for going down.
+func undertrickPenalty(c Contract, vulnerability Vulnerability, undertricks int) int {
+	if !c.Doubled && !c.Redoubled {
+		return undertricks * vulnerableChoice(vulnerability, 50, 100)
+	}
+
+	// Doubled: not vulnerable 100, 200, 200, then 300 for each further
+	// undertrick; vulnerable 200, then 300 each.
+	penalty := 0
+	vul := vulnerability == Vulnerable
+	for i := 1; i <= undertricks; i++ {
+		switch {
+		case vul && i == 1:
+			penalty += 200
+		case vul:
+			penalty += 300
+		case i == 1:
+			penalty += 100
+		case i <= 3:
+			penalty += 200
+		default:
+			penalty += 300
+		}
+	}
+	if c.Redoubled {
+		penalty *= 2
+	}
+	return penalty
+}
+
+// vulnerableChoice returns nonVul or vul depending on the vulnerability.
+func vulnerableChoice(vulnerability Vulnerability, nonVul, vul int) int {
+	if vulnerability {
+		return vul
+	}
+	return nonVul
 }
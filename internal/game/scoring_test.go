@@ -58,3 +58,70 @@ func TestCalculateScore(t *testing.T) {
 		})
 	}
 }
+
+// Expected totals are taken from the ACBL/WBF duplicate scoring tables.
+func TestScoreContract(t *testing.T) {
+	tests := []struct {
+		name     string
+		contract Contract
+		vul      Vulnerability
+		tricks   int
+		want     int
+	}{
+		{"1NT=", Contract{Level: 1, Strain: NoTrump}, NotVulnerable, 7, 90},
+		{"1NT+1", Contract{Level: 1, Strain: NoTrump}, NotVulnerable, 8, 120},
+		{"2C+2", Contract{Level: 2, Strain: Clubs}, Vulnerable, 10, 130},
+		{"3NT= vul", Contract{Level: 3, Strain: NoTrump}, Vulnerable, 9, 600},
+		{"3NT+1", Contract{Level: 3, Strain: NoTrump}, NotVulnerable, 10, 430},
+		{"4S=", Contract{Level: 4, Strain: Spades}, NotVulnerable, 10, 420},
+		{"4S+1 vul", Contract{Level: 4, Strain: Spades}, Vulnerable, 11, 650},
+		{"5C= vul", Contract{Level: 5, Strain: Clubs}, Vulnerable, 11, 600},
+		{"6C=", Contract{Level: 6, Strain: Clubs}, NotVulnerable, 12, 920},
+		{"6NT= vul", Contract{Level: 6, Strain: NoTrump}, Vulnerable, 12, 1440},
+		{"7NT= vul", Contract{Level: 7, Strain: NoTrump}, Vulnerable, 13, 2220},
+		{"1CX+1", Contract{Level: 1, Strain: Clubs, Doubled: true}, NotVulnerable, 8, 240},
+		{"1CX+1 vul", Contract{Level: 1, Strain: Clubs, Doubled: true}, Vulnerable, 8, 340},
+		{"2HX= into game", Contract{Level: 2, Strain: Hearts, Doubled: true}, NotVulnerable, 8, 470},
+		{"2HX= vul into game", Contract{Level: 2, Strain: Hearts, Doubled: true}, Vulnerable, 8, 670},
+		{"5CX= vul", Contract{Level: 5, Strain: Clubs, Doubled: true}, Vulnerable, 11, 750},
+		{"1CXX=", Contract{Level: 1, Strain: Clubs, Redoubled: true}, NotVulnerable, 7, 230},
+		{"1NTXX+1 vul", Contract{Level: 1, Strain: NoTrump, Redoubled: true}, Vulnerable, 8, 1160},
+		{"2SXX=", Contract{Level: 2, Strain: Spades, Redoubled: true}, NotVulnerable, 8, 640},
+		{"4S-1", Contract{Level: 4, Strain: Spades}, NotVulnerable, 9, -50},
+		{"4S-2 vul", Contract{Level: 4, Strain: Spades}, Vulnerable, 8, -200},
+		{"4SX-1", Contract{Level: 4, Strain: Spades, Doubled: true}, NotVulnerable, 9, -100},
+		{"4SX-2", Contract{Level: 4, Strain: Spades, Doubled: true}, NotVulnerable, 8, -300},
+		{"4SX-3", Contract{Level: 4, Strain: Spades, Doubled: true}, NotVulnerable, 7, -500},
+		{"4SX-4", Contract{Level: 4, Strain: Spades, Doubled: true}, NotVulnerable, 6, -800},
+		{"4SX-1 vul", Contract{Level: 4, Strain: Spades, Doubled: true}, Vulnerable, 9, -200},
+		{"4SX-3 vul", Contract{Level: 4, Strain: Spades, Doubled: true}, Vulnerable, 7, -800},
+		{"4SXX-2", Contract{Level: 4, Strain: Spades, Redoubled: true}, NotVulnerable, 8, -600},
+		{"4SXX-4 vul", Contract{Level: 4, Strain: Spades, Redoubled: true}, Vulnerable, 6, -2200},
+		{"7NTX-13", Contract{Level: 7, Strain: NoTrump, Doubled: true}, NotVulnerable, 0, -3500},
+		{"7NTX-13 vul", Contract{Level: 7, Strain: NoTrump, Doubled: true}, Vulnerable, 0, -3800},
+		{"Passed out", Contract{PassedOut: true}, Vulnerable, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoreContract(tt.contract, tt.vul, tt.tricks)
+			if got.TotalScore != tt.want {
+				t.Errorf("ScoreContract() TotalScore = %v, want %v", got.TotalScore, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreContract_Breakdown(t *testing.T) {
+	got := ScoreContract(Contract{Level: 3, Strain: Hearts, Doubled: true}, Vulnerable, 10)
+	want := Score{TrickScore: 180, OvertrickScore: 200, BonusScore: 550, TotalScore: 930, Overtricks: 1, MadeGame: true}
+	if got != want {
+		t.Errorf("ScoreContract() = %+v, want %+v", got, want)
+	}
+
+	got = ScoreContract(Contract{Level: 3, Strain: NoTrump}, NotVulnerable, 7)
+	want = Score{PenaltyScore: 100, TotalScore: -100, Undertricks: 2}
+	if got != want {
+		t.Errorf("ScoreContract() = %+v, want %+v", got, want)
+	}
+}
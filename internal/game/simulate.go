@@ -0,0 +1,233 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// ErrNoLayouts is returned when no layout of the hidden hands is found in
+// which the computer bids as the auction went.
+var ErrNoLayouts = errors.New("no layout of the hidden hands fits the auction")
+
+// DefaultBidLayouts is the number of simulated layouts calls are judged on
+// when no other number is given.
+const DefaultBidLayouts = 12
+
+// maxLayoutAttempts is how many random layouts sampleLayouts tries before
+// settling for the ones it has found.
+const maxLayoutAttempts = 200000
+
+// sampleLayouts deals the cards seat cannot see at random, keeping up to n
+// layouts in which the computer, playing the partnerships' systems, would
+// have bid the other three hands as they were bid in the auction. Fewer
+// are returned when such layouts are rare. Once ctx is done it stops and
+// returns the context's error.
+func sampleLayouts(ctx context.Context, seat Position, hand *Hand, auction *Auction, systems Systems, n int, r *rand.Rand) ([]Deal, error) {
+	var deck Deck
+	for _, c := range NewDeck() {
+		if !hand.Contains(c) {
+			deck = append(deck, c)
+		}
+	}
+
+	var layouts []Deal
+	for i := 0; i < maxLayoutAttempts && len(layouts) < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		deck.ShuffleWith(r)
+		var d Deal
+		d.Hands[seat] = hand
+		for k, pos := 1, (seat+1)%4; pos != seat; k, pos = k+1, (pos+1)%4 {
+			d.Hands[pos] = NewHand(deck[(k-1)*13 : k*13])
+		}
+		if fitsAuction(d, seat, auction, systems) {
+			layouts = append(layouts, d)
+		}
+	}
+	return layouts, nil
+}
+
+// fitsAuction reports whether the computer bids the hands of a layout
+// other than seat's as they were bid in the auction.
+func fitsAuction(d Deal, seat Position, auction *Auction, systems Systems) bool {
+	var players [4]*Player
+	replay := NewAuction()
+	replay.Vulnerability = auction.Vulnerability
+	for _, b := range auction.Bids {
+		if b.Position != seat {
+			if players[b.Position] == nil {
+				players[b.Position] = NewPlayer(b.Position)
+				players[b.Position].Deal(d.Hands[b.Position].Cards)
+				players[b.Position].System = systems[b.Position.Side()]
+			}
+			if !sameCall(players[b.Position].MakeBid(replay), b) {
+				return false
+			}
+		}
+		replay.AddBid(b)
+	}
+	return true
+}
+
+// sameCall reports whether two calls are the same, whoever made them.
+func sameCall(a, b Bid) bool {
+	return a.Pass == b.Pass && a.Double == b.Double && a.Redouble == b.Redouble &&
+		a.Level == b.Level && (a.Level == 0 || a.Strain == b.Strain)
+}
+
+// bidOut returns a copy of the auction bid to its end by the computer with
+// the four hands of a deal, turn being the next seat to call.
+func bidOut(d Deal, auction *Auction, turn Position, systems Systems) *Auction {
+	players := NewPlayers(d)
+	systems.Seat(players)
+	out := &Auction{Bids: append([]Bid(nil), auction.Bids...), Vulnerability: auction.Vulnerability}
+	for ; !out.IsOver(); turn = (turn + 1) % 4 {
+		bid := players[turn].MakeBid(out)
+		bid.Position = turn
+		out.AddBid(bid)
+	}
+	return out
+}
+
+// nextToCall returns the seat whose turn it is to call.
+func nextToCall(auction *Auction, dealer Position) Position {
+	if n := len(auction.Bids); n > 0 {
+		return (auction.Bids[n-1].Position + 1) % 4
+	}
+	return dealer
+}
+
+// CallResult is how a call fares over the simulated layouts, once the
+// computer has bid each layout to its end and the contract is played
+// double dummy.
+type CallResult struct {
+	Call     Bid
+	Score    float64  // Average score for the caller's side
+	IMPs     float64  // Average IMPs gained against the recommended call
+	Contract Contract // Most frequent final contract
+}
+
+// CallEvaluation compares calls at one point of an auction.
+type CallEvaluation struct {
+	Recommended Bid          // The computer's call, which IMPs are measured against
+	Layouts     int          // Number of layouts the calls were judged on
+	Results     []CallResult // The recommended call first, then the others in the order given
+}
+
+// EvaluateCalls judges calls for the seat on turn, holding hand, at this
+// point of the auction on a board where the partnerships play systems.
+// The hidden hands are dealt at random, keeping up to layouts deals in
+// which the computer would have bid as the others did. On each, every call is followed by the computer bidding the
+// auction out, and the final contract is scored double dummy. The layouts
+// are solved concurrently. The computer's own call is always evaluated.
+// Once ctx is done the sampling and solving stop, and the context's error
+// is returned.
+func EvaluateCalls(ctx context.Context, board Board, systems Systems, hand *Hand, auction *Auction, calls []Bid, layouts int, r *rand.Rand) (CallEvaluation, error) {
+	seat := nextToCall(auction, board.Dealer)
+	ai := NewPlayer(seat)
+	ai.Deal(hand.Cards)
+	ai.System = systems[seat.Side()]
+	recommended := ai.MakeBid(auction)
+	candidates := []Bid{recommended}
+	for _, c := range calls {
+		if err := auction.ValidateBid(c); err != nil {
+			return CallEvaluation{}, err
+		}
+		dup := false
+		for _, seen := range candidates {
+			dup = dup || sameCall(seen, c)
+		}
+		if !dup {
+			candidates = append(candidates, c)
+		}
+	}
+	for i := range candidates {
+		candidates[i].Position = seat
+	}
+
+	deals, err := sampleLayouts(ctx, seat, hand, auction, systems, layouts, r)
+	if err != nil {
+		return CallEvaluation{}, err
+	}
+	if len(deals) == 0 {
+		return CallEvaluation{}, ErrNoLayouts
+	}
+
+	// scores[i][j] is candidate j's score on layout i.
+	scores := make([][]int, len(deals))
+	contracts := make([][]Contract, len(deals))
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				scores[i], contracts[i], _ = scoreCalls(ctx, board, systems, deals[i], auction, candidates)
+			}
+		}()
+	}
+	for i := 0; i < len(deals) && ctx.Err() == nil; i++ {
+		select {
+		case work <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(work)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return CallEvaluation{}, err
+	}
+
+	eval := CallEvaluation{Recommended: candidates[0], Layouts: len(deals)}
+	for j, call := range candidates {
+		res := CallResult{Call: call}
+		seen := make(map[Contract]int)
+		for i := range deals {
+			res.Score += float64(scores[i][j])
+			res.IMPs += float64(IMPs(scores[i][j] - scores[i][0]))
+			seen[contracts[i][j]]++
+			if seen[contracts[i][j]] > seen[res.Contract] {
+				res.Contract = contracts[i][j]
+			}
+		}
+		res.Score /= float64(len(deals))
+		res.IMPs /= float64(len(deals))
+		eval.Results = append(eval.Results, res)
+	}
+	return eval, nil
+}
+
+// scoreCalls bids a layout out after each call and returns the final
+// contracts with their double-dummy scores for the caller's side. Each
+// strain and declarer is solved once. It gives up with the context's error
+// once ctx is done.
+func scoreCalls(ctx context.Context, board Board, systems Systems, d Deal, auction *Auction, calls []Bid) ([]int, []Contract, error) {
+	side := calls[0].Position.Side()
+	scores := make([]int, len(calls))
+	contracts := make([]Contract, len(calls))
+	solved := make(map[[2]int]int)
+	for j, call := range calls {
+		a := &Auction{Bids: append(append([]Bid(nil), auction.Bids...), call), Vulnerability: auction.Vulnerability}
+		c, _ := NewContract(bidOut(d, a, (call.Position+1)%4, systems))
+		contracts[j] = c
+		if c.PassedOut {
+			continue
+		}
+		key := [2]int{int(c.Strain), int(c.Declarer)}
+		tricks, ok := solved[key]
+		if !ok {
+			var err error
+			if tricks, err = SolveTricks(ctx, d, c.Strain, c.Declarer); err != nil {
+				return nil, nil, err
+			}
+			solved[key] = tricks
+		}
+		scores[j] = SideScore(c, ScoreContract(c, board.Vulnerable(c.Declarer), tricks), side)
+	}
+	return scores, contracts, nil
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestEvaluateCalls(t *testing.T) {
+	d := testDeal(t)
+	board := NewBoard(3) // South deals
+	auction := NewAuction()
+	recommended := NewPlayers(d)[South].MakeBid(auction)
+	grand := NewBid(7, NoTrump)
+
+	eval, err := EvaluateCalls(context.Background(), board, Systems{}, d.Hands[South], auction, []Bid{recommended, grand}, 2, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eval.Layouts != 2 || len(eval.Results) != 2 {
+		t.Fatalf("EvaluateCalls() judged %d calls on %d layouts, want 2 on 2", len(eval.Results), eval.Layouts)
+	}
+	if first := eval.Results[0]; !sameCall(first.Call, recommended) || first.IMPs != 0 || first.Call.Position != South {
+		t.Errorf("first result is %s by %s, %.1f IMPs; want the recommended %s by South, 0 IMPs",
+			first.Call, first.Call.Position, first.IMPs, recommended)
+	}
+	slam := eval.Results[1]
+	if c := slam.Contract; c.Level != 7 || c.Strain != NoTrump || c.Declarer.Side() != NorthSouth {
+		t.Errorf("after 7NT the contract is %s", c)
+	}
+	// Twelve points opposite an unknown hand do not make a grand slam.
+	if slam.Score >= eval.Results[0].Score || slam.IMPs >= 0 {
+		t.Errorf("7NT scores %.0f (%+.1f IMPs), the recommended %s %.0f", slam.Score, slam.IMPs, recommended, eval.Results[0].Score)
+	}
+
+	auction.AddBid(Bid{Level: 1, Strain: Spades, Position: South})
+	if _, err := EvaluateCalls(context.Background(), board, Systems{}, d.Hands[West], auction, []Bid{NewBid(1, Clubs)}, 2, rand.New(rand.NewSource(1))); !errors.Is(err, ErrInsufficientBid) {
+		t.Errorf("EvaluateCalls(1C over 1S) error = %v, want ErrInsufficientBid", err)
+	}
+}
+
+func TestEvaluateCalls_Cancelled(t *testing.T) {
+	d := testDeal(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := EvaluateCalls(ctx, NewBoard(3), Systems{}, d.Hands[South], NewAuction(), []Bid{NewBid(7, NoTrump)}, 2, rand.New(rand.NewSource(1)))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("EvaluateCalls() error = %v once cancelled, want context.Canceled", err)
+	}
+}
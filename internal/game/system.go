@@ -0,0 +1,907 @@
+package game
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BiddingSystem decides the computer's calls. *System implements it with
+// rules written as data; a system can also be written in Go.
+type BiddingSystem interface {
+	String() string                       // The system's name
+	Call(p *Player, auction *Auction) Bid // The call p makes next
+}
+
+// Systems gives the bidding system each partnership plays, indexed by
+// Side. A nil entry stands for PolishClub.
+type Systems [2]BiddingSystem
+
+// For returns the system a partnership plays.
+func (s Systems) For(side Side) BiddingSystem {
+	if s[side] != nil {
+		return s[side]
+	}
+	return PolishClub
+}
+
+// Seat gives each player their partnership's bidding system.
+func (s Systems) Seat(players []*Player) {
+	for _, p := range players {
+		p.System = s[p.Position.Side()]
+	}
+}
+
+//go:embed systems/*.json
+var systemFiles embed.FS
+
+// The bidding systems built into the program, read from the systems
+// directory.
+var (
+	// PolishClub is the system the computer plays unless given another.
+	PolishClub = mustLoadBuiltinSystem("polish-club")
+	// PolishClubMulti opens the Multi 2♦, a weak two in either major.
+	PolishClubMulti = mustLoadBuiltinSystem("polish-club-multi")
+	// SAYC is the Standard American Yellow Card.
+	SAYC = mustLoadBuiltinSystem("sayc")
+	// TwoOverOne is 2/1 Game Forcing, written as changes to SAYC.
+	TwoOverOne = mustLoadBuiltinSystem("two-over-one")
+)
+
+// BuiltinSystems returns the bidding systems built into the program.
+func BuiltinSystems() []*System {
+	return []*System{PolishClub, PolishClubMulti, SAYC, TwoOverOne}
+}
+
+// FindSystem looks up a built-in bidding system by its ID, such as
+// "two-over-one", or its name, such as "2/1 Game Forcing", ignoring case.
+func FindSystem(name string) (*System, bool) {
+	return findSystem(BuiltinSystems(), name)
+}
+
+// Defenses to the opponents' 1NT opening a partnership may play instead
+// of the natural one the built-in systems share: a penalty double and
+// long suits. Each holds only rules over 1NT, and is played with a system
+// by PlayNTDefense.
+var (
+	// Landy bids 2♣ with both majors.
+	Landy = mustLoadBuiltinSystem("landy")
+	// Cappelletti bids 2♣ with one long suit and the other bids with two.
+	Cappelletti = mustLoadBuiltinSystem("cappelletti")
+	// DONT doubles with one long suit and bids the lower of two suits.
+	DONT = mustLoadBuiltinSystem("dont")
+)
+
+// NaturalNTDefense names the defense to 1NT the built-in systems play
+// unless a partnership is given another.
+const NaturalNTDefense = "natural"
+
+// NTDefenses returns the defenses to 1NT built into the program.
+func NTDefenses() []*System {
+	return []*System{Landy, Cappelletti, DONT}
+}
+
+// FindNTDefense looks up a built-in defense to 1NT by its ID or name,
+// ignoring case. NaturalNTDefense is found as nil, as the systems play it
+// already.
+func FindNTDefense(name string) (*System, bool) {
+	if strings.EqualFold(name, NaturalNTDefense) {
+		return nil, true
+	}
+	return findSystem(NTDefenses(), name)
+}
+
+// findSystem looks up a system in a list by its ID or name.
+func findSystem(list []*System, name string) (*System, bool) {
+	for _, s := range list {
+		if strings.EqualFold(name, s.ID) || strings.EqualFold(name, s.Name) {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// PlayNTDefense has a partnership play a defense to 1NT, whose rules are
+// tried before those of its system. A nil defense leaves the system as
+// it is.
+func (s *Systems) PlayNTDefense(side Side, defense *System) error {
+	if defense == nil {
+		return nil
+	}
+	sys, ok := s.For(side).(*System)
+	if !ok {
+		return fmt.Errorf("%s is not written as rules, so cannot play %s", s.For(side), defense)
+	}
+	s[side] = sys.With(defense)
+	return nil
+}
+
+// With returns a copy of the system playing a convention, whose rules are
+// tried first.
+func (s *System) With(convention *System) *System {
+	rules := make([]Rule, 0, len(convention.Rules)+len(s.Rules))
+	rules = append(append(rules, convention.Rules...), s.Rules...)
+	return &System{
+		ID:      s.ID,
+		Name:    s.Name + " with " + convention.Name,
+		Extends: s.Extends,
+		Rules:   rules,
+	}
+}
+
+// Contexts a rule applies in, worked out from who has called so far.
+// Opponents may have called since partner's last call, in which case only
+// rules with an opponents pattern apply.
+const (
+	ContextOpening  = "opening"  // Nobody has bid yet
+	ContextOvercall = "overcall" // An opponent has bid; our side has only passed
+	ContextResponse = "response" // Partner opened; we have only passed
+	ContextAdvance  = "advance"  // Partner overcalled or doubled; we have only passed
+	ContextRebid    = "rebid"    // We have bid before
+)
+
+// System is a bidding system written as data. Its rules are tried in
+// order and the first one that fits the auction and the hand gives the
+// call, provided the call is legal. When no rule fits, the computer
+// passes.
+type System struct {
+	ID      string `json:"-"` // File name of a built-in system, without .json
+	Name    string `json:"name"`
+	Extends string `json:"extends,omitempty"` // ID of a built-in system whose rules are tried after these
+	Rules   []Rule `json:"rules"`
+}
+
+// String returns the system's name.
+func (s *System) String() string {
+	return s.Name
+}
+
+// Call returns the call the system makes for p.
+func (s *System) Call(p *Player, auction *Auction) Bid {
+	context, contested := p.biddingContext(auction)
+	return s.choose(p, auction, context, contested)
+}
+
+// Rule is one agreement of a bidding system.
+//
+// Auction is a pattern for the calls the partnership has made so far,
+// opponents' calls left out, e.g. "1C 1D" for opener's rebid after a 1♣
+// opening and a 1♦ response. Passes before the partnership's first bid
+// are ignored, and a pattern starting with "..." may follow any earlier
+// calls. Each call is written as in "2NT", "P", "X" or "XX", or
+// "?" for any call. A bid's level may be "*" for any level or "3+" for
+// the 3 level or higher. Its strain may be a suit variable instead of
+// C, D, H, S or NT: M stands for a major, m for a minor, and x and y for
+// any suit, different variables for different suits. The variables can
+// then be used in Call and in the hand constraint.
+//
+// Opponents is a pattern for the opponents' calls, written the same way
+// with their passes left out, e.g. "1x" once they have opened at the 1
+// level and not bid since, or "1x ?" once they have called again. Its suit
+// variables are shared with Auction. A rule without one applies only when
+// the opponents did not open and have not called since partner's last
+// call.
+//
+// Call is written the same way, without wildcards; a bid without a level,
+// such as "x", is made at the cheapest legal level, or Jump levels higher.
+type Rule struct {
+	Context   string         `json:"context"`             // opening, overcall, response, advance or rebid
+	Auction   string         `json:"auction,omitempty"`   // Pattern for the partnership's calls so far; empty for any
+	Opponents string         `json:"opponents,omitempty"` // Pattern for the opponents' calls other than passes
+	Hand      HandConstraint `json:"hand"`
+	Call      string         `json:"call,omitempty"`    // The call to make
+	Jump      int            `json:"jump,omitempty"`    // Levels to skip, for a call without a level
+	Action    string         `json:"action,omitempty"`  // A convention worked out in code, instead of Call
+	Forcing   bool           `json:"forcing,omitempty"` // Partner may not pass the call
+	Alert     bool           `json:"alert,omitempty"`   // The call is artificial or conventional
+	Meaning   string         `json:"meaning,omitempty"` // What the call shows, for people reading the system
+
+	pattern   auctionPattern
+	opponents auctionPattern
+	call      callPattern
+}
+
+// HandConstraint is what a rule asks of the hand. The fields shared with
+// SeatConstraint work as they do there. Lengths restricts the suits
+// bound to variables of the auction patterns; a variable the patterns
+// leave free stands for the hand's longest suit that fits, the higher
+// ranking of equal suits. Unbid restricts every suit nobody has bid, and
+// Stoppers lists suits, by letter or variable, that must be stopped.
+// Honours restricts how many of the ace, king and queen a suit holds, and
+// Vulnerable whether our side is vulnerable. Aces and KeyCards list the
+// counts allowed; key cards are the four aces and the king of the last
+// suit bid, whose queen TrumpQueen asks for. Combined restricts the
+// hand's HCP added to the fewest partner has shown in the auction.
+// Points restricts the hand's value by the metrics it names, such as
+// "ltc" or "quick-tricks", whose bounds may have fractions, and
+// SuitQuality a suit's length plus its honours.
+type HandConstraint struct {
+	SeatConstraint
+	Lengths     map[string]Range      `json:"lengths,omitempty"`
+	Unbid       *Range                `json:"unbid,omitempty"`
+	Stoppers    []string              `json:"stoppers,omitempty"`
+	Honours     map[string]Range      `json:"honours,omitempty"`
+	Vulnerable  *bool                 `json:"vulnerable,omitempty"`
+	Aces        []int                 `json:"aces,omitempty"`
+	KeyCards    []int                 `json:"keyCards,omitempty"`
+	TrumpQueen  *bool                 `json:"trumpQueen,omitempty"`
+	Combined    *Range                `json:"combined,omitempty"`
+	Points      map[string]FloatRange `json:"points,omitempty"`
+	SuitQuality map[string]Range      `json:"suitQuality,omitempty"`
+}
+
+// ruleActions are conventions too involved to write as rules. A rule names
+// one in its Action, and the call it returns is made if it is legal.
+var ruleActions = map[string]func(p *Player, auction *Auction) (Bid, bool){
+	"cue-bid":    (*Player).cueBid,
+	"place-slam": (*Player).placeSlam,
+}
+
+// ParseSystem reads a bidding system written as JSON.
+func ParseSystem(data []byte) (*System, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var s System
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("bidding system: %w", err)
+	}
+	if s.Name == "" {
+		return nil, fmt.Errorf("bidding system: missing name")
+	}
+	for i := range s.Rules {
+		if err := s.Rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("bidding system %s: rule %d: %w", s.Name, i+1, err)
+		}
+	}
+	if s.Extends != "" {
+		base, err := loadBuiltinSystem(s.Extends)
+		if err != nil {
+			return nil, fmt.Errorf("bidding system %s: extends %q: %w", s.Name, s.Extends, err)
+		}
+		s.Rules = append(s.Rules, base.Rules...)
+	}
+	return &s, nil
+}
+
+// LoadSystemFile reads a bidding system from a JSON file.
+func LoadSystemFile(path string) (*System, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSystem(data)
+}
+
+// loadBuiltinSystem reads the built-in system with an ID. It parses the
+// file afresh rather than looking the system up, as systems extending
+// another are themselves built in.
+func loadBuiltinSystem(id string) (*System, error) {
+	data, err := systemFiles.ReadFile("systems/" + id + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no built-in system %q", id)
+	}
+	s, err := ParseSystem(data)
+	if err != nil {
+		return nil, err
+	}
+	s.ID = id
+	return s, nil
+}
+
+// mustLoadBuiltinSystem reads a system built into the program.
+func mustLoadBuiltinSystem(id string) *System {
+	s, err := loadBuiltinSystem(id)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// compile checks a rule and prepares its auction pattern and call.
+func (r *Rule) compile() error {
+	switch r.Context {
+	case ContextOpening, ContextResponse, ContextRebid:
+	case ContextOvercall, ContextAdvance:
+		if r.Opponents == "" {
+			return fmt.Errorf("%s rules need an opponents pattern", r.Context)
+		}
+	default:
+		return fmt.Errorf("unknown context %q", r.Context)
+	}
+
+	var err error
+	if r.pattern, err = parseAuctionPattern(r.Auction); err != nil {
+		return fmt.Errorf("auction %q: %w", r.Auction, err)
+	}
+	if r.Opponents != "" {
+		if r.opponents, err = parseAuctionPattern(r.Opponents); err != nil {
+			return fmt.Errorf("opponents %q: %w", r.Opponents, err)
+		}
+		r.opponents.skipPasses = true
+	}
+	bound := r.pattern.variables()
+	for v, ok := range r.opponents.variables() {
+		bound[v] = bound[v] || ok
+	}
+	for name := range r.Hand.Lengths {
+		v := variableIndex(name)
+		if v < 0 {
+			return fmt.Errorf("lengths: %q is not a suit variable", name)
+		}
+		bound[v] = true // Bound by the hand if not by the auction
+	}
+
+	switch {
+	case r.Call != "" && r.Action != "":
+		return fmt.Errorf("has both a call and an action")
+	case r.Action != "":
+		if ruleActions[r.Action] == nil {
+			return fmt.Errorf("unknown action %q", r.Action)
+		}
+	case r.Call == "":
+		return fmt.Errorf("has neither a call nor an action")
+	default:
+		if r.call, err = parseCallPattern(r.Call); err != nil {
+			return fmt.Errorf("call %q: %w", r.Call, err)
+		}
+		if r.call.kind == anyCall || r.call.atLeast || (r.call.kind == contractCall && r.call.level < 0) {
+			return fmt.Errorf("call %q: wildcards are only allowed in the auction", r.Call)
+		}
+		if v := r.call.variable; v >= 0 && !bound[v] {
+			return fmt.Errorf("call %q: suit variable %s is not in the auction or the lengths", r.Call, suitVariables[v].name)
+		}
+	}
+	if r.Jump != 0 {
+		if r.Jump < 0 || r.call.kind != contractCall || r.call.level != 0 {
+			return fmt.Errorf("jump %d needs a bid without a level", r.Jump)
+		}
+		r.call.jump = r.Jump
+	}
+
+	for _, name := range r.Hand.Stoppers {
+		if err := checkSuitName(name, bound); err != nil {
+			return fmt.Errorf("stoppers: %w", err)
+		}
+	}
+	for name := range r.Hand.Honours {
+		if err := checkSuitName(name, bound); err != nil {
+			return fmt.Errorf("honours: %w", err)
+		}
+	}
+	for name := range r.Hand.SuitQuality {
+		if err := checkSuitName(name, bound); err != nil {
+			return fmt.Errorf("suitQuality: %w", err)
+		}
+	}
+	for name := range r.Hand.Points {
+		if _, ok := handMetrics[name]; !ok {
+			return fmt.Errorf("points: unknown metric %q", name)
+		}
+	}
+	return nil
+}
+
+// checkSuitName checks a suit named in a hand constraint by letter or by
+// a variable the rule binds.
+func checkSuitName(name string, bound [numSuitVariables]bool) error {
+	if _, ok := parseSuitLetter(name); ok {
+		return nil
+	}
+	v := variableIndex(name)
+	if v < 0 {
+		return fmt.Errorf("unknown suit %q", name)
+	}
+	if !bound[v] {
+		return fmt.Errorf("suit variable %s is not in the auction", name)
+	}
+	return nil
+}
+
+// biddingContext works out which rules apply to p. It also reports whether
+// the auction is contested: the opponents opened, or have bid, doubled or
+// redoubled since partner's last call.
+func (p *Player) biddingContext(auction *Auction) (context string, contested bool) {
+	side := p.Position.Side()
+	weBid, iBid, theyBid := false, false, false // Calls other than passes
+	last := -1                                  // The last call other than an opponent's pass
+	for i, b := range auction.Bids {
+		if b.Position.Side() == side {
+			weBid = weBid || !b.Pass
+			iBid = iBid || (!b.Pass && b.Position == p.Position)
+			last = i
+		} else if !b.Pass {
+			theyBid = true
+			last = i
+		}
+	}
+	switch {
+	case !weBid && !theyBid:
+		return ContextOpening, false
+	case !weBid:
+		return ContextOvercall, true
+	}
+	contested = opener(auction).Side() != side || auction.Bids[last].Position != p.Position.Partner()
+	switch {
+	case iBid:
+		return ContextRebid, contested
+	case opener(auction).Side() != side:
+		return ContextAdvance, contested
+	}
+	return ContextResponse, contested
+}
+
+// opener returns the seat that made the first call other than a pass.
+// The auction must have one.
+func opener(auction *Auction) Position {
+	for _, b := range auction.Bids {
+		if !b.Pass {
+			return b.Position
+		}
+	}
+	panic("game: opener of an auction with no bids")
+}
+
+// choose returns the call the system makes for p in a context.
+func (s *System) choose(p *Player, auction *Auction, context string, contested bool) Bid {
+	hcp, lengths := handShape(p.Hand)
+	for i := range s.Rules {
+		r := &s.Rules[i]
+		var vars bindings
+		if !r.applies(auction, p.Position.Side(), context, contested, &vars) || !r.Hand.fits(p, auction, hcp, lengths, &vars) {
+			continue
+		}
+		var bid Bid
+		if r.Action != "" {
+			var ok bool
+			if bid, ok = ruleActions[r.Action](p, auction); !ok {
+				continue
+			}
+		} else {
+			bid = r.call.resolve(auction, &vars)
+		}
+		if auction.IsValidBid(bid) {
+			partner := p.PartnerPicture(auction)
+			bid.Explanation = r.explain(&vars, partner)
+			return bid
+		}
+	}
+	return explainedPass("No agreement fits the hand")
+}
+
+// Explain returns what a call p makes next means in the system, whoever
+// chose it: the first rule making the call that fits p's hand explains
+// it, or failing that the first rule making the call whatever the hand.
+// It returns nil when no rule makes the call.
+func (s *System) Explain(p *Player, auction *Auction, bid Bid) *Explanation {
+	context, contested := p.biddingContext(auction)
+	hcp, lengths := handShape(p.Hand)
+	partner := p.PartnerPicture(auction)
+	var fallback *Explanation
+	for i := range s.Rules {
+		r := &s.Rules[i]
+		var vars bindings
+		if !r.applies(auction, p.Position.Side(), context, contested, &vars) {
+			continue
+		}
+		if r.Action != "" {
+			if !r.Hand.fits(p, auction, hcp, lengths, &vars) {
+				continue
+			}
+			if got, ok := ruleActions[r.Action](p, auction); ok && sameCall(got, bid) {
+				return r.explain(&vars, partner)
+			}
+			continue
+		}
+		fitted := vars
+		if r.Hand.fits(p, auction, hcp, lengths, &fitted) && sameCall(r.call.resolve(auction, &fitted), bid) {
+			return r.explain(&fitted, partner)
+		}
+		if fallback == nil {
+			if v := r.call.variable; v >= 0 && !vars.bound[v] && !vars.bind(v, bid.Strain) {
+				continue
+			}
+			if sameCall(r.call.resolve(auction, &vars), bid) {
+				fallback = r.explain(&vars, partner)
+			}
+		}
+	}
+	return fallback
+}
+
+// applies reports whether a rule is for the auction so far, binding the
+// suit variables of its patterns. contested tells whether the opponents
+// opened or have called since partner's last call.
+func (r *Rule) applies(auction *Auction, side Side, context string, contested bool, vars *bindings) bool {
+	if r.Context != context {
+		return false
+	}
+	if r.Opponents == "" {
+		if contested {
+			return false
+		}
+	} else if !r.opponents.match(auction, side.Opponents(), vars) {
+		return false
+	}
+	return r.pattern.match(auction, side, vars)
+}
+
+// explainedPass returns a pass the system makes for want of a rule.
+func explainedPass(why string) Bid {
+	bid := NewPass()
+	bid.Explanation = &Explanation{Meaning: why}
+	return bid
+}
+
+// handShape counts a hand's high card points and suit lengths.
+func handShape(h *Hand) (hcp int, lengths [4]int) {
+	for _, c := range h.Cards {
+		if c.Rank >= Jack {
+			hcp += int(c.Rank - Ten)
+		}
+		lengths[c.Suit]++
+	}
+	return hcp, lengths
+}
+
+// fits checks the hand of p against the constraint, with the suit
+// variables bound by the rule's auction pattern.
+func (c *HandConstraint) fits(p *Player, auction *Auction, hcp int, lengths [4]int, vars *bindings) bool {
+	if !c.SeatConstraint.fits(hcp, lengths) {
+		return false
+	}
+	for v, sv := range suitVariables {
+		r, ok := c.Lengths[sv.name]
+		switch {
+		case !ok:
+		case vars.bound[v]:
+			if !r.Contains(lengths[vars.suit[v]]) {
+				return false
+			}
+		case !vars.bindLongest(v, r, lengths):
+			return false
+		}
+	}
+	if c.Unbid != nil {
+		var bid [4]bool
+		for _, b := range auction.Bids {
+			if !b.Pass && !b.Double && !b.Redouble && b.Strain < NoTrump {
+				bid[b.Strain] = true
+			}
+		}
+		for s := Clubs; s <= Spades; s++ {
+			if !bid[s] && !c.Unbid.Contains(lengths[s]) {
+				return false
+			}
+		}
+	}
+	for _, name := range c.Stoppers {
+		if !p.Hand.HasStopper(vars.named(name)) {
+			return false
+		}
+	}
+	for name, r := range c.Honours {
+		if !r.Contains(p.Hand.TopHonours(vars.named(name))) {
+			return false
+		}
+	}
+	for name, r := range c.SuitQuality {
+		if !r.Contains(p.Hand.SuitQuality(vars.named(name))) {
+			return false
+		}
+	}
+	for name, r := range c.Points {
+		if !r.Contains(handMetrics[name].Value(p.Hand)) {
+			return false
+		}
+	}
+	if c.Combined != nil && !c.Combined.Contains(hcp+p.PartnerPicture(auction).HCP.Min) {
+		return false
+	}
+	if c.Vulnerable != nil && bool(auction.Vulnerability.IsVulnerable(p.Position.Side())) != *c.Vulnerable {
+		return false
+	}
+	if c.Aces != nil {
+		aces, _ := countKeyCards(p.Hand, NoTrump)
+		if !containsInt(c.Aces, aces) {
+			return false
+		}
+	}
+	if c.KeyCards != nil || c.TrumpQueen != nil {
+		keyCards, queen := countKeyCards(p.Hand, p.determineTrumpSuit(auction))
+		if c.KeyCards != nil && !containsInt(c.KeyCards, keyCards) {
+			return false
+		}
+		if c.TrumpQueen != nil && queen != *c.TrumpQueen {
+			return false
+		}
+	}
+	return true
+}
+
+// containsInt reports whether v is in list.
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// suitVariables are the names a pattern may give a suit, with the suits
+// each may stand for.
+var suitVariables = []struct {
+	name   string
+	allows func(Suit) bool
+}{
+	{"M", func(s Suit) bool { return s == Hearts || s == Spades }},
+	{"m", func(s Suit) bool { return s == Clubs || s == Diamonds }},
+	{"x", func(s Suit) bool { return s < NoTrump }},
+	{"y", func(s Suit) bool { return s < NoTrump }},
+	{"z", func(s Suit) bool { return s < NoTrump }},
+}
+
+// numSuitVariables is the number of suit variables.
+const numSuitVariables = 5
+
+// variableIndex returns the index of a suit variable, or -1.
+func variableIndex(name string) int {
+	for i, v := range suitVariables {
+		if v.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// bindings holds the suits the variables of a pattern stand for.
+type bindings struct {
+	suit  [numSuitVariables]Suit
+	bound [numSuitVariables]bool
+}
+
+// bind makes variable v stand for suit s, if it may.
+func (b *bindings) bind(v int, s Suit) bool {
+	if b.bound[v] {
+		return b.suit[v] == s
+	}
+	if !suitVariables[v].allows(s) {
+		return false
+	}
+	for w := range b.suit {
+		if b.bound[w] && b.suit[w] == s {
+			return false
+		}
+	}
+	b.suit[v], b.bound[v] = s, true
+	return true
+}
+
+// named returns the suit named by letter or by a bound variable.
+func (b *bindings) named(name string) Suit {
+	if s, ok := parseSuitLetter(name); ok {
+		return s
+	}
+	return b.suit[variableIndex(name)]
+}
+
+// bindLongest makes variable v stand for the longest suit it may whose
+// length is in r, the higher ranking of equal suits.
+func (b *bindings) bindLongest(v int, r Range, lengths [4]int) bool {
+	best := NoTrump
+	for s := Spades; s >= Clubs; s-- {
+		trial := *b
+		if r.Contains(lengths[s]) && trial.bind(v, s) && (best == NoTrump || lengths[s] > lengths[best]) {
+			best = s
+		}
+	}
+	return best != NoTrump && b.bind(v, best)
+}
+
+// callKind is the kind of call a callPattern matches.
+type callKind int
+
+const (
+	anyCall callKind = iota
+	passCall
+	doubleCall
+	redoubleCall
+	contractCall
+)
+
+// callPattern is one call of an auction pattern, or the call of a rule.
+type callPattern struct {
+	kind     callKind
+	level    int  // 0 for the cheapest level, -1 for any level
+	jump     int  // Levels above the cheapest, when level is 0
+	atLeast  bool // level is a minimum
+	strain   Suit
+	variable int // Index of the suit variable standing for the strain, or -1
+}
+
+// parseCallPattern reads a call such as "2NT", "P", "3+M" or "x".
+func parseCallPattern(s string) (callPattern, error) {
+	cp := callPattern{variable: -1}
+	switch s {
+	case "?":
+		return cp, nil
+	case "P":
+		cp.kind = passCall
+		return cp, nil
+	case "X":
+		cp.kind = doubleCall
+		return cp, nil
+	case "XX":
+		cp.kind = redoubleCall
+		return cp, nil
+	}
+
+	cp.kind = contractCall
+	strain := strings.TrimLeft(s, "0123456789+*")
+	level := s[:len(s)-len(strain)]
+	switch {
+	case level == "":
+	case level == "*":
+		cp.level = -1
+	default:
+		cp.atLeast = strings.HasSuffix(level, "+")
+		n, err := strconv.Atoi(strings.TrimSuffix(level, "+"))
+		if err != nil || n < 1 || n > 7 {
+			return cp, fmt.Errorf("invalid level %q", level)
+		}
+		cp.level = n
+	}
+	if st, ok := parseSuitLetter(strain); ok {
+		cp.strain = st
+	} else if strain == "NT" {
+		cp.strain = NoTrump
+	} else if cp.variable = variableIndex(strain); cp.variable < 0 {
+		return cp, fmt.Errorf("invalid strain %q", strain)
+	}
+	return cp, nil
+}
+
+// parseSuitLetter reads C, D, H or S.
+func parseSuitLetter(s string) (Suit, bool) {
+	switch s {
+	case "C":
+		return Clubs, true
+	case "D":
+		return Diamonds, true
+	case "H":
+		return Hearts, true
+	case "S":
+		return Spades, true
+	}
+	return 0, false
+}
+
+// matches reports whether a call fits the pattern, binding its suit
+// variable if it has one.
+func (cp callPattern) matches(b Bid, vars *bindings) bool {
+	switch cp.kind {
+	case anyCall:
+		return true
+	case passCall:
+		return b.Pass
+	case doubleCall:
+		return b.Double
+	case redoubleCall:
+		return b.Redouble
+	}
+	if b.Pass || b.Double || b.Redouble {
+		return false
+	}
+	switch {
+	case cp.level < 0:
+	case cp.atLeast:
+		if b.Level < cp.level {
+			return false
+		}
+	case b.Level != cp.level:
+		return false
+	}
+	if cp.variable >= 0 {
+		return vars.bind(cp.variable, b.Strain)
+	}
+	return b.Strain == cp.strain
+}
+
+// resolve turns the call of a rule into a bid, filling in the suit its
+// variable stands for and the cheapest level if none is given.
+func (cp callPattern) resolve(auction *Auction, vars *bindings) Bid {
+	switch cp.kind {
+	case passCall:
+		return NewPass()
+	case doubleCall:
+		return NewDouble()
+	case redoubleCall:
+		return NewRedouble()
+	}
+	strain := cp.strain
+	if cp.variable >= 0 {
+		strain = vars.suit[cp.variable]
+	}
+	level := cp.level
+	if level == 0 {
+		level = 1
+		if last, ok := auction.LastNonPassBid(); ok {
+			level = last.Level
+			if strain <= last.Strain {
+				level++
+			}
+		}
+		level += cp.jump
+	}
+	return NewBid(level, strain)
+}
+
+// auctionPattern matches the calls a partnership has made.
+type auctionPattern struct {
+	calls      []callPattern
+	open       bool // Earlier calls may come before the pattern
+	skipPasses bool // Passes are left out, as for the opponents' calls
+}
+
+// parseAuctionPattern reads a pattern such as "1C 1D 2NT" or "... 4NT".
+func parseAuctionPattern(s string) (auctionPattern, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return auctionPattern{open: true}, nil
+	}
+	var ap auctionPattern
+	if fields[0] == "..." {
+		ap.open = true
+		fields = fields[1:]
+	}
+	for _, f := range fields {
+		cp, err := parseCallPattern(f)
+		if err != nil {
+			return ap, err
+		}
+		if cp.kind == contractCall && cp.level == 0 {
+			return ap, fmt.Errorf("call %q needs a level", f)
+		}
+		ap.calls = append(ap.calls, cp)
+	}
+	return ap, nil
+}
+
+// variables reports which suit variables the pattern binds.
+func (ap auctionPattern) variables() [numSuitVariables]bool {
+	var bound [numSuitVariables]bool
+	for _, cp := range ap.calls {
+		if cp.variable >= 0 {
+			bound[cp.variable] = true
+		}
+	}
+	return bound
+}
+
+// match reports whether the calls side has made in the auction fit the
+// pattern, binding the suit variables it uses.
+func (ap auctionPattern) match(auction *Auction, side Side, vars *bindings) bool {
+	i := len(auction.Bids) - 1
+	for k := len(ap.calls) - 1; k >= 0; k-- {
+		for i >= 0 && (auction.Bids[i].Position.Side() != side || (ap.skipPasses && auction.Bids[i].Pass)) {
+			i--
+		}
+		if i < 0 || !ap.calls[k].matches(auction.Bids[i], vars) {
+			return false
+		}
+		i--
+	}
+	if ap.open {
+		return true
+	}
+	for ; i >= 0; i-- {
+		if b := auction.Bids[i]; b.Position.Side() == side && !b.Pass {
+			return false
+		}
+	}
+	return true
+}
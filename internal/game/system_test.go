@@ -0,0 +1,366 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// systemAuction builds an auction from calls written as in PBN, made in
+// turn starting with North.
+func systemAuction(t *testing.T, calls string) *Auction {
+	t.Helper()
+	a := NewAuction()
+	for i, s := range strings.Fields(calls) {
+		b, err := parseCall(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Position = Position(i % 4)
+		a.AddBid(b)
+	}
+	return a
+}
+
+// systemPlayer seats a player holding a hand written as spades.hearts.diamonds.clubs.
+func systemPlayer(t *testing.T, pos Position, hand string) *Player {
+	t.Helper()
+	cards, err := parseHolding(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPlayer(pos)
+	p.Deal(cards)
+	return p
+}
+
+// expectCall checks the call bidder makes next playing each of systems,
+// with want written as in PBN, and stops the test if any is wrong. It
+// returns the call made playing the last system.
+func expectCall(t *testing.T, bidder *Player, auction *Auction, want string, systems ...*System) Bid {
+	t.Helper()
+	call, err := parseCall(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bid Bid
+	for _, sys := range systems {
+		bidder.System = sys
+		if bid = bidder.MakeBid(auction); !sameCall(bid, call) {
+			t.Errorf("%s: expected %s, got %s", sys, call, bid)
+		}
+	}
+	if t.Failed() {
+		t.FailNow()
+	}
+	return bid
+}
+
+func TestParseSystem_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"no name", `{"rules": []}`, "missing name"},
+		{"unknown field", `{"name": "T", "rules": [{"context": "opening", "cal": "1C"}]}`, "unknown field"},
+		{"bad context", `{"name": "T", "rules": [{"context": "sandwich", "call": "1C"}]}`, "unknown context"},
+		{"overcall without opponents", `{"name": "T", "rules": [{"context": "overcall", "call": "X"}]}`, "opponents pattern"},
+		{"bad strain", `{"name": "T", "rules": [{"context": "rebid", "auction": "1C 1Q", "call": "P"}]}`, "invalid strain"},
+		{"no level in auction", `{"name": "T", "rules": [{"context": "rebid", "auction": "1C H", "call": "P"}]}`, "needs a level"},
+		{"wildcard call", `{"name": "T", "rules": [{"context": "opening", "call": "*H"}]}`, "wildcards"},
+		{"unbound variable", `{"name": "T", "rules": [{"context": "rebid", "auction": "1C 1D", "call": "2M"}]}`, "not in the auction"},
+		{"unbound stopper", `{"name": "T", "rules": [{"context": "rebid", "auction": "1C", "hand": {"stoppers": ["x"]}, "call": "P"}]}`, "not in the auction"},
+		{"jump with a level", `{"name": "T", "rules": [{"context": "rebid", "auction": "1x", "call": "2x", "jump": 1}]}`, "without a level"},
+		{"unknown action", `{"name": "T", "rules": [{"context": "rebid", "action": "psyche"}]}`, "unknown action"},
+		{"call and action", `{"name": "T", "rules": [{"context": "rebid", "call": "P", "action": "cue-bid"}]}`, "both"},
+		{"empty range", `{"name": "T", "rules": [{"context": "opening", "hand": {"hcp": [17, 15]}, "call": "1NT"}]}`, "is empty"},
+		{"unknown honours suit", `{"name": "T", "rules": [{"context": "opening", "hand": {"honours": {"Q": [1, 3]}}, "call": "2S"}]}`, "unknown suit"},
+		{"empty fractional range", `{"name": "T", "rules": [{"context": "opening", "hand": {"points": {"cccc": [12.5, 10]}}, "call": "1S"}]}`, "is empty"},
+		{"unknown metric", `{"name": "T", "rules": [{"context": "opening", "hand": {"points": {"bergen": [20, 40]}}, "call": "1S"}]}`, "unknown metric"},
+		{"unbound suit quality", `{"name": "T", "rules": [{"context": "opening", "hand": {"suitQuality": {"x": [8, 13]}}, "call": "2S"}]}`, "not in the auction"},
+		{"unknown base", `{"name": "T", "extends": "acol", "rules": []}`, "no built-in system"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSystem([]byte(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseSystem() error = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuctionPattern_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		calls   string // Made in turn from North; North-South is the partnership
+		want    bool
+	}{
+		{"1C 1D", "1C Pass 1D Pass", true},
+		{"1C 1D", "Pass Pass 1C Pass 1D Pass", true}, // Passes before the first bid are ignored
+		{"1C 1D", "1C Pass 1D Pass 2NT Pass", false},
+		{"1C 1D 2NT", "1C 1S 1D 2S 2NT Pass", true}, // Opponents' calls are left out
+		{"... 4NT", "1S Pass 3S Pass 4NT Pass", true},
+		{"... 4NT", "1S Pass 4NT Pass 5H Pass", false},
+		{"1C 1M", "1C Pass 1H Pass", true},
+		{"1C 1M", "1C Pass 1NT Pass", false},
+		{"1m 2m", "1D Pass 2D Pass", true},
+		{"1x 2y", "1D Pass 2D Pass", false}, // Different variables, different suits
+		{"1x 2y", "1D Pass 2C Pass", true},
+		{"... 3+x", "1C Pass 1D Pass 3H Pass", true},
+		{"... 3+x", "1C Pass 1D Pass 2H Pass", false},
+		{"1NT *x", "1NT Pass 4S Pass", true},
+		{"1NT ?", "1NT Pass X Pass", true},
+		{"1C X", "1C 1S X Pass", true},
+	}
+	for _, tt := range tests {
+		ap, err := parseAuctionPattern(tt.pattern)
+		if err != nil {
+			t.Fatalf("parseAuctionPattern(%q): %v", tt.pattern, err)
+		}
+		var vars bindings
+		if got := ap.match(systemAuction(t, tt.calls), NorthSouth, &vars); got != tt.want {
+			t.Errorf("%q against %q = %v, want %v", tt.pattern, tt.calls, got, tt.want)
+		}
+	}
+}
+
+func TestSystem_CustomRules(t *testing.T) {
+	sys, err := ParseSystem([]byte(`{
+		"name": "Test",
+		"rules": [
+			{"context": "opening", "hand": {"hcp": [14, 16], "balanced": true}, "call": "1NT"},
+			{"context": "response", "auction": "1x", "hand": {"hcp": [6, 9], "lengths": {"x": [4, 13]}}, "call": "x"},
+			{"context": "response", "auction": "1x", "hand": {"hcp": [10, 12], "stoppers": ["C", "D", "H", "S"]}, "call": "2NT"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A 14-count opens 1NT in this system, and 1♣ in Polish Club.
+	opener := systemPlayer(t, North, "AQ32.K54.K76.Q43")
+	if bid := opener.MakeBid(NewAuction()); bid.Level != 1 || bid.Strain != Clubs {
+		t.Errorf("Polish Club opening = %s, want 1C", bid)
+	}
+	opener.System = sys
+	if bid := opener.MakeBid(NewAuction()); bid.Level != 1 || bid.Strain != NoTrump {
+		t.Errorf("custom opening = %s, want 1NT", bid)
+	}
+
+	// A raise is made at the cheapest level in the suit bound to x.
+	responder := systemPlayer(t, South, "J432.Q5.K543.J43")
+	responder.System = sys
+	auction := NewAuction()
+	auction.AddBid(Bid{Level: 1, Strain: Spades, Position: North})
+	if bid := responder.MakeBid(auction); bid.Level != 2 || bid.Strain != Spades {
+		t.Errorf("raise = %s, want 2S", bid)
+	}
+
+	// 2NT needs every suit stopped.
+	stopped := systemPlayer(t, South, "K32.Q54.A76.Q543")
+	stopped.System = sys
+	if bid := stopped.MakeBid(auction); bid.Level != 2 || bid.Strain != NoTrump {
+		t.Errorf("with all suits stopped = %s, want 2NT", bid)
+	}
+	unstopped := systemPlayer(t, South, "K32.54.AK76.Q543")
+	unstopped.System = sys
+	if bid := unstopped.MakeBid(auction); !bid.Pass {
+		t.Errorf("with hearts unstopped = %s, want Pass", bid)
+	}
+}
+
+func TestSystem_SkipsIllegalCalls(t *testing.T) {
+	sys, err := ParseSystem([]byte(`{
+		"name": "Test",
+		"rules": [
+			{"context": "response", "call": "1S"},
+			{"context": "response", "call": "2S"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := systemPlayer(t, South, "AQ32.K54.Q76.J43")
+	p.System = sys
+	auction := systemAuction(t, "1NT Pass")
+	if bid := p.MakeBid(auction); bid.Level != 2 || bid.Strain != Spades {
+		t.Errorf("over 1NT = %s, want 2S from the second rule", bid)
+	}
+}
+
+func TestBiddingContext(t *testing.T) {
+	tests := []struct {
+		calls     string // Made in turn from North; the player is the next to call
+		want      string
+		contested bool
+	}{
+		{"", ContextOpening, false},
+		{"Pass Pass", ContextOpening, false},
+		{"Pass 1H", ContextOvercall, true},
+		{"1H Pass Pass", ContextOvercall, true}, // In the pass-out seat
+		{"1NT Pass", ContextResponse, false},
+		{"Pass Pass 1NT Pass", ContextResponse, false}, // A passed hand responds
+		{"Pass 1H 1S Pass", ContextAdvance, true},
+		{"Pass 1H X Pass", ContextAdvance, true},
+		{"1C Pass 1D Pass", ContextRebid, false},
+		{"Pass 1H 1S Pass 2H Pass", ContextRebid, true},
+		{"1NT 2S", ContextResponse, true},
+		{"1H X", ContextResponse, true},
+		{"1C Pass 1D X", ContextRebid, true},
+		{"1H 1S 2H Pass", ContextRebid, false}, // They have not called since
+		{"Pass 1H 1S 2H", ContextAdvance, true},
+	}
+	for _, tt := range tests {
+		a := systemAuction(t, tt.calls)
+		p := NewPlayer(Position(len(a.Bids) % 4))
+		got, contested := p.biddingContext(a)
+		if got != tt.want || contested != tt.contested {
+			t.Errorf("context after %q = %q, %v, want %q, %v", tt.calls, got, contested, tt.want, tt.contested)
+		}
+	}
+}
+
+func TestSystem_Opponents(t *testing.T) {
+	sys, err := ParseSystem([]byte(`{
+		"name": "Test",
+		"rules": [
+			{"context": "overcall", "opponents": "1x", "hand": {"lengths": {"x": [0, 1], "y": [4, 13]}}, "call": "y", "jump": 1},
+			{"context": "overcall", "opponents": "1x", "hand": {"lengths": {"x": [0, 2]}, "unbid": [3, 13]}, "call": "X"},
+			{"context": "response", "call": "1NT"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		calls string // Made in turn from North; the player is the next to call
+		hand  string
+		want  string
+	}{
+		{"1H", "K32.2.AQ765.J5432", "3D"},          // Diamonds outrank clubs of the same length
+		{"1H", "KQ32.32.A765.J43", "Double"},       // Three or more in every unbid suit
+		{"1H", "KQ32.32.A7654.J4", "Pass"},         // Only two clubs
+		{"1H Pass Pass", "KQ32.2.A76.J5432", "3C"}, // Their passes are left out
+		{"1H Pass 2H", "KQ32.2.A76.J5432", "Pass"}, // They have bid twice
+		{"1C Pass", "KQ32.2.A76.J5432", "1NT"},     // Rules without opponents apply when we opened
+	}
+	for _, tt := range tests {
+		a := systemAuction(t, tt.calls)
+		p := systemPlayer(t, Position(len(a.Bids)%4), tt.hand)
+		p.System = sys
+		if got := p.MakeBid(a).String(); got != tt.want {
+			t.Errorf("after %q with %s = %s, want %s", tt.calls, tt.hand, got, tt.want)
+		}
+	}
+}
+
+func TestFindSystem(t *testing.T) {
+	tests := []struct {
+		name string
+		want *System
+	}{
+		{"sayc", SAYC},
+		{"Polish-Club", PolishClub},
+		{"polish club with multi 2d", PolishClubMulti},
+		{"2/1 game forcing", TwoOverOne},
+		{"two-over-one", TwoOverOne},
+		{"acol", nil},
+	}
+	for _, tt := range tests {
+		if got, _ := FindSystem(tt.name); got != tt.want {
+			t.Errorf("FindSystem(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseSystem_Extends(t *testing.T) {
+	sys, err := ParseSystem([]byte(`{
+		"name": "Weak NT",
+		"extends": "sayc",
+		"rules": [{"context": "opening", "hand": {"hcp": [12, 14], "balanced": true}, "call": "1NT"}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sys.Rules) != len(SAYC.Rules)+1 {
+		t.Errorf("%d rules, want the system's own and SAYC's %d", len(sys.Rules), len(SAYC.Rules))
+	}
+	p := systemPlayer(t, North, "AQ32.K54.Q76.J43")
+	p.System = sys
+	if bid := p.MakeBid(NewAuction()); bid.Level != 1 || bid.Strain != NoTrump {
+		t.Errorf("12-14 balanced opening = %s, want 1NT from the system's own rule", bid)
+	}
+	p = systemPlayer(t, North, "AKQ2.AK4.AK6.K43")
+	p.System = sys
+	if bid := p.MakeBid(NewAuction()); bid.Level != 2 || bid.Strain != Clubs {
+		t.Errorf("26-count opening = %s, want SAYC's 2C", bid)
+	}
+}
+
+func TestSystems_Seat(t *testing.T) {
+	players := NewPlayers(NewRandomDeal(rand.New(rand.NewSource(1))))
+	Systems{SAYC, nil}.Seat(players)
+	for _, p := range players {
+		want := BiddingSystem(SAYC)
+		if p.Position.Side() == EastWest {
+			want = nil
+		}
+		if p.System != want {
+			t.Errorf("%s plays %v, want %v", p.Position, p.System, want)
+		}
+	}
+
+	// An 11-count with five hearts opens in Polish Club but not in SAYC.
+	hand := "AQ3.K5432.76.Q43"
+	ns, ew := systemPlayer(t, North, hand), systemPlayer(t, East, hand)
+	Systems{SAYC, nil}.Seat([]*Player{ns, ew})
+	if bid := ns.MakeBid(NewAuction()); !bid.Pass {
+		t.Errorf("North playing SAYC opens %s, want Pass", bid)
+	}
+	if bid := ew.MakeBid(systemAuction(t, "Pass")); bid.Level != 1 || bid.Strain != Hearts {
+		t.Errorf("East playing Polish Club opens %s, want 1H", bid)
+	}
+}
+
+func TestFindNTDefense(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  *System
+		found bool
+	}{
+		{"natural", nil, true},
+		{"Landy", Landy, true},
+		{"cappelletti", Cappelletti, true},
+		{"dont", DONT, true},
+		{"astro", nil, false},
+	}
+	for _, tt := range tests {
+		if got, found := FindNTDefense(tt.name); got != tt.want || found != tt.found {
+			t.Errorf("FindNTDefense(%q) = %v, %v, want %v, %v", tt.name, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestSystems_PlayNTDefense(t *testing.T) {
+	s := Systems{SAYC, nil}
+	if err := s.PlayNTDefense(NorthSouth, DONT); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PlayNTDefense(EastWest, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.For(NorthSouth).String(); got != "SAYC with DONT" {
+		t.Errorf("North-South play %s, want SAYC with DONT", got)
+	}
+	if got := s.For(EastWest); got != PolishClub {
+		t.Errorf("East-West play %s, want Polish Club as it was", got)
+	}
+	if n := len(s.For(NorthSouth).(*System).Rules); n != len(DONT.Rules)+len(SAYC.Rules) {
+		t.Errorf("%d rules, want DONT's and SAYC's", n)
+	}
+	if len(SAYC.Rules) == 0 || SAYC.Name != "SAYC" {
+		t.Errorf("SAYC itself changed to %s", SAYC)
+	}
+}
@@ -1,33 +1,122 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
-	gamepkg "github.com/marekforys/bridge-bid-tutor-go/internal/game"
 	"github.com/google/uuid"
+	gamepkg "github.com/marekforys/bridge-bid-tutor-go/internal/game"
 )
 
 // Server holds HTTP state and session store
 type Server struct {
-	mu       sync.RWMutex
-	sessions map[string]*Session
+	mu          sync.RWMutex
+	sessions    map[string]*Session
+	leads       map[string]*gamepkg.LeadProblem // Opening lead problems, by ID
+	bidEvals    map[string]*bidEvaluation       // Bid simulations, by ID
+	bidEvalIdle time.Duration                   // How long a bid simulation is kept without being polled
+	nextBoard   int                             // Board number given to the next session that doesn't ask for one
+	work        *workPool                       // Double-dummy work run in the background
+	ctx         context.Context                 // Done once the server is closed
+	stop        context.CancelFunc
+}
+
+// bidEvaluation is the simulation of a bid, run in the background so that
+// the request that starts it need not wait for the double-dummy solving.
+type bidEvaluation struct {
+	cancel context.CancelFunc
+	done   chan struct{}  // Closed once the simulation has finished
+	result map[string]any // The evaluation and explanation, once done
+	err    error          // Why the simulation stopped short, once done
+	idle   *time.Timer    // Drops the simulation once the client stops polling it
+}
+
+// maxBackgroundJobs caps the bid simulations and par solves run at once.
+// Each of them already spreads its solving over every core.
+const maxBackgroundJobs = 4
+
+// workPool bounds the double-dummy work the server runs in the background.
+type workPool struct {
+	slots chan struct{}
+}
+
+// newWorkPool returns a pool running at most n jobs at once.
+func newWorkPool(n int) *workPool {
+	return &workPool{slots: make(chan struct{}, n)}
+}
+
+// tryGo runs f in the background if the pool has room, and reports
+// whether it does.
+func (p *workPool) tryGo(f func()) bool {
+	select {
+	case p.slots <- struct{}{}:
+		go p.run(f)
+		return true
+	default:
+		return false
+	}
+}
+
+// goWhenFree runs f in the background once the pool has room. If ctx is
+// done first f runs anyway, without taking room, so that it can stop with
+// the context's error.
+func (p *workPool) goWhenFree(ctx context.Context, f func()) {
+	go func() {
+		select {
+		case p.slots <- struct{}{}:
+			p.run(f)
+		case <-ctx.Done():
+			f()
+		}
+	}()
+}
+
+// run runs f and then frees its room in the pool.
+func (p *workPool) run(f func()) {
+	defer func() { <-p.slots }()
+	f()
 }
 
 // Session captures a single table's state
 type Session struct {
-	ID      string              `json:"id"`
-	Players []*gamepkg.Player   `json:"-"`
-	Auction *gamepkg.Auction    `json:"-"`
-	Dealer  gamepkg.Position    `json:"-"`
+	ID      string                `json:"id"`
+	Board   gamepkg.Board         `json:"-"`
+	Deal    gamepkg.Deal          `json:"-"`
+	Players []*gamepkg.Player     `json:"-"`
+	Systems gamepkg.Systems       `json:"-"` // Bidding systems the partnerships play
+	Auction *gamepkg.Auction      `json:"-"`
+	Dealer  gamepkg.Position      `json:"-"` // Player whose turn it is to call
+	Play    *gamepkg.Play         `json:"-"` // Card play, started once the auction produces a contract
+	Tricks  *gamepkg.PendingTable `json:"-"` // Double-dummy tricks, solved in the background once the auction is over
 }
 
 // New constructs a new Server
 func New() *Server {
-	return &Server{sessions: make(map[string]*Session)}
+	ctx, stop := context.WithCancel(context.Background())
+	return &Server{
+		sessions:    make(map[string]*Session),
+		leads:       make(map[string]*gamepkg.LeadProblem),
+		bidEvals:    make(map[string]*bidEvaluation),
+		bidEvalIdle: bidEvaluationIdle,
+		nextBoard:   1,
+		work:        newWorkPool(maxBackgroundJobs),
+		ctx:         ctx,
+		stop:        stop,
+	}
+}
+
+// Close stops the server's background work: bid simulations and the
+// double-dummy solves behind par.
+func (s *Server) Close() {
+	s.stop()
 }
 
 // RegisterRoutes attaches handlers to the mux
@@ -35,10 +124,28 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/sessions", s.handleSessions)
 	mux.HandleFunc("/api/sessions/", s.handleSessionByID)
 	mux.HandleFunc("/api/evaluate-bid", s.handleEvaluateBid)
+	mux.HandleFunc("/api/evaluate-bid/", s.handleBidEvaluationByID)
+	mux.HandleFunc("/api/leads", s.handleLeads)
+	mux.HandleFunc("/api/leads/", s.handleLeadByID)
+	mux.HandleFunc("/api/systems", s.handleSystems)
+	mux.HandleFunc("/api/nt-defenses", s.handleNTDefenses)
 }
 
+// maxDealAttempts caps the random deals tried for a session with
+// constraints, a tenth of the generator's default, so that constraints few
+// deals meet fail within a fraction of a second rather than tying up the
+// request.
+const maxDealAttempts = 100000
+
 // handleSessions manages collection endpoints
 // POST /api/sessions -> create a new session
+// Optional JSON body: {"board": 5, "deal": "<29-digit deal ID>", "constraints": {"North": {"hcp": [15, 17]}}, "pbn": "<PBN file>", "lin": "<LIN file or handviewer link>",
+// "systems": {"NS": "sayc", "EW": "polish-club"}, "ntDefenses": {"NS": "dont"}}.
+// Without a board number the next one in sequence is used. A deal ID replays that deal;
+// otherwise the cards are shuffled until every seat matches its constraints, which
+// fails with 422 when they can't be met or no deal is found in maxDealAttempts.
+// A PBN or LIN file supplies the board and hands: the board with the requested number, or its first board.
+// A partnership without a bidding system plays Polish Club, and without a 1NT defense the natural one.
 func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 	setCORSHeaders(w)
 	if r.Method == http.MethodOptions {
@@ -50,7 +157,84 @@ func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sess := s.newSession()
+	var req struct {
+		Board       int                                `json:"board"`
+		Deal        string                             `json:"deal"`
+		Constraints map[string]*gamepkg.SeatConstraint `json:"constraints"`
+		PBN         string                             `json:"pbn"`
+		LIN         string                             `json:"lin"`
+		Systems     map[string]string                  `json:"systems"`
+		NTDefenses  map[string]string                  `json:"ntDefenses"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+	if req.Board < 0 {
+		http.Error(w, "board number must be positive", http.StatusBadRequest)
+		return
+	}
+	systems, err := parseSystems(req.Systems, req.NTDefenses)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.PBN != "" || req.LIN != "" {
+		var records []gamepkg.BoardRecord
+		var err error
+		if req.PBN != "" {
+			records, err = gamepkg.ReadPBN(strings.NewReader(req.PBN))
+		} else {
+			records, err = gamepkg.ParseLIN(req.LIN)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rec, err := pickRecord(records, req.Board)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sess := s.newSession(rec.Board, rec.Deal, systems)
+		s.sessPut(sess)
+		writeJSON(w, http.StatusCreated, s.serializeSession(sess))
+		return
+	}
+
+	var deal gamepkg.Deal
+	switch {
+	case req.Deal != "":
+		d, err := gamepkg.DealFromID(req.Deal)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		deal = d
+	case len(req.Constraints) > 0:
+		var dc gamepkg.DealConstraints
+		for seat, c := range req.Constraints {
+			pos, err := parsePosition(seat)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			dc[pos] = c
+		}
+		gen := gamepkg.NewDealGenerator(time.Now().UnixNano(), dc)
+		gen.MaxAttempts = maxDealAttempts
+		d, err := gen.Next(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		deal = d
+	default:
+		deal = gamepkg.NewShuffledDeal()
+	}
+
+	sess := s.newSession(s.pickBoard(req.Board), deal, systems)
 	s.sessPut(sess)
 
 	writeJSON(w, http.StatusCreated, s.serializeSession(sess))
@@ -59,6 +243,9 @@ func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 // handleSessionByID manages single-session endpoints
 // GET /api/sessions/{id}
 // POST /api/sessions/{id}/bid
+// POST /api/sessions/{id}/play
+// GET /api/sessions/{id}/pbn
+// GET /api/sessions/{id}/lin
 func (s *Server) handleSessionByID(w http.ResponseWriter, r *http.Request) {
 	setCORSHeaders(w)
 	if r.Method == http.MethodOptions {
@@ -98,36 +285,111 @@ func (s *Server) handleSessionByID(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		s.handlePostBid(w, r, sess)
+	case "play":
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		s.handlePostPlay(w, r, sess)
+	case "pbn":
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleGetPBN(w, sess)
+	case "lin":
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleGetLIN(w, sess)
+	case "inference":
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, http.StatusOK, s.serializeInference(sess.Auction))
 	default:
 		w.WriteHeader(http.StatusNotFound)
 	}
 }
 
-// newSession constructs a new session with a shuffled deck, dealt hands, and a fresh auction
-func (s *Server) newSession() *Session {
-	deck := gamepkg.NewDeck()
-	deck.Shuffle()
+// pickRecord returns the board with the given number from an imported
+// file, or the first board if number is zero.
+func pickRecord(records []gamepkg.BoardRecord, number int) (gamepkg.BoardRecord, error) {
+	if len(records) == 0 {
+		return gamepkg.BoardRecord{}, errors.New("file contains no boards")
+	}
+	if number == 0 {
+		return records[0], nil
+	}
+	for _, rec := range records {
+		if rec.Board.Number == number {
+			return rec, nil
+		}
+	}
+	return gamepkg.BoardRecord{}, fmt.Errorf("board %d not found in file", number)
+}
 
-	players := make([]*gamepkg.Player, 4)
-	for i := 0; i < 4; i++ {
-		players[i] = gamepkg.NewPlayer(gamepkg.Position(i))
+// sessionRecord describes the session's board, auction and play for export
+func sessionRecord(sess *Session) gamepkg.BoardRecord {
+	rec := gamepkg.NewBoardRecord(sess.Board, sess.Deal, sess.Auction)
+	if sess.Play != nil {
+		rec.Play = sess.Play.Cards()
+		if sess.Play.IsOver() {
+			rec.Result = sess.Play.DeclarerTricks()
+		}
 	}
+	return rec
+}
 
-	// Deal 52 cards round-robin
-	for i := 0; i < 52; i++ {
-		players[i%4].Hand.Cards = append(players[i%4].Hand.Cards, deck[i])
+// handleGetPBN downloads the session's board and auction as a PBN file
+func (s *Server) handleGetPBN(w http.ResponseWriter, sess *Session) {
+	rec := sessionRecord(sess)
+	w.Header().Set("Content-Type", "application/x-pbn; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="board-%d.pbn"`, sess.Board.Number))
+	if err := gamepkg.WritePBN(w, []gamepkg.BoardRecord{rec}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
-	// Sort hands
-	for _, p := range players {
-		p.Hand.Sort()
+}
+
+// handleGetLIN downloads the session's board and auction as a LIN file
+func (s *Server) handleGetLIN(w http.ResponseWriter, sess *Session) {
+	rec := sessionRecord(sess)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="board-%d.lin"`, sess.Board.Number))
+	if err := gamepkg.WriteLIN(w, []gamepkg.BoardRecord{rec}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// pickBoard returns the requested board, or the next board in sequence if number is zero
+func (s *Server) pickBoard(number int) gamepkg.Board {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if number == 0 {
+		number = s.nextBoard
 	}
+	s.nextBoard = number + 1
+	return gamepkg.NewBoard(number)
+}
 
+// newSession constructs a new session for the given board and deal with a fresh auction
+func (s *Server) newSession(board gamepkg.Board, deal gamepkg.Deal, systems gamepkg.Systems) *Session {
 	id := uuid.New().String()
+	players := gamepkg.NewPlayers(deal)
+	systems.Seat(players)
+	auction := gamepkg.NewAuction()
+	auction.Vulnerability = board.Vulnerability
 	return &Session{
 		ID:      id,
+		Board:   board,
+		Deal:    deal,
 		Players: players,
-		Auction: gamepkg.NewAuction(),
-		Dealer:  gamepkg.North,
+		Systems: systems,
+		Auction: auction,
+		Dealer:  board.Dealer,
 	}
 }
 
@@ -163,54 +425,302 @@ func (s *Server) handlePostBid(w http.ResponseWriter, r *http.Request, sess *Ses
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	if !sess.Auction.IsValidBid(bid) {
-		http.Error(w, "invalid bid relative to auction", http.StatusBadRequest)
+	if err := sess.Auction.ValidateBid(bid); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	bid.Explanation = current.ExplainBid(sess.Auction, bid)
 	bid.Position = current.Position
 	sess.Auction.AddBid(bid)
 	sess.Dealer = (sess.Dealer + 1) % 4
 
+	// Once the auction produces a contract, the card play begins
+	if c, err := gamepkg.NewContract(sess.Auction); err == nil && !c.PassedOut {
+		sess.Play, _ = gamepkg.NewPlay(c, sess.Deal)
+	}
+	// Par needs the deal solved double dummy, which can take seconds, so
+	// the session reports it pending until the table is ready. The solve
+	// waits its turn with the other background work.
+	if sess.Auction.IsOver() {
+		tricks := gamepkg.NewPendingTable()
+		ctx, cancel := context.WithTimeout(s.ctx, parSolveTimeout)
+		deal := sess.Deal
+		s.work.goWhenFree(ctx, func() {
+			defer cancel()
+			tricks.Solve(ctx, deal)
+		})
+		sess.Tricks = tricks
+	}
+
+	writeJSON(w, http.StatusOK, s.serializeSession(sess))
+}
+
+// parSolveTimeout bounds how long par may wait for and take solving.
+const parSolveTimeout = 2 * time.Minute
+
+// handlePostPlay plays a card for the seat on turn. Declarer plays dummy's
+// cards by giving dummy's position.
+// Expects JSON: {"position":"North|East|South|West","card":"AS|10H|7C"}
+func (s *Server) handlePostPlay(w http.ResponseWriter, r *http.Request, sess *Session) {
+	setCORSHeaders(w)
+	var req struct {
+		Position string `json:"position"`
+		Card     string `json:"card"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+	if sess.Play == nil {
+		http.Error(w, "the auction has not produced a contract", http.StatusConflict)
+		return
+	}
+
+	pos, err := parsePosition(req.Position)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	card, err := gamepkg.ParseCard(req.Card)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := sess.Play.PlayCard(pos, card); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, gamepkg.ErrNotYourTurn) || errors.Is(err, gamepkg.ErrPlayOver) {
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
 	writeJSON(w, http.StatusOK, s.serializeSession(sess))
 }
 
 // Serialization helpers
 func (s *Server) serializeSession(sess *Session) map[string]any {
-	bids := make([]map[string]any, 0, len(sess.Auction.Bids))
-	for _, b := range sess.Auction.Bids {
+	players := []map[string]any{}
+	for _, p := range sess.Players {
+		players = append(players, s.serializeHand(p.Position, p.Hand))
+	}
+
+	return map[string]any{
+		"id":       sess.ID,
+		"dealId":   sess.Deal.ID(),
+		"dealer":   sess.Dealer.String(),
+		"players":  players,
+		"auction":  s.serializeAuction(sess.Auction),
+		"complete": sess.Auction.IsOver(),
+		"contract": s.serializeContract(sess),
+		"play":     s.serializePlay(sess),
+		"par":      s.serializePar(sess),
+		"systems":  s.serializeSystems(sess.Systems),
+		"board": map[string]any{
+			"number":        sess.Board.Number,
+			"dealer":        sess.Board.Dealer.String(),
+			"vulnerability": sess.Board.Vulnerability.String(),
+		},
+	}
+}
+
+// serializeSystems names the bidding system each partnership plays
+func (s *Server) serializeSystems(systems gamepkg.Systems) map[string]any {
+	return map[string]any{
+		"NS": systems.For(gamepkg.NorthSouth).String(),
+		"EW": systems.For(gamepkg.EastWest).String(),
+	}
+}
+
+// serializeAuction lists the calls made so far
+func (s *Server) serializeAuction(a *gamepkg.Auction) []map[string]any {
+	bids := make([]map[string]any, 0, len(a.Bids))
+	for _, b := range a.Bids {
 		bids = append(bids, map[string]any{
-			"position": b.Position.String(),
-			"level":    b.Level,
-			"strain":   s.strainString(b.Strain),
-			"double":   b.Double,
-			"redouble": b.Redouble,
-			"pass":     b.Pass,
+			"position":    b.Position.String(),
+			"level":       b.Level,
+			"strain":      s.strainString(b.Strain),
+			"double":      b.Double,
+			"redouble":    b.Redouble,
+			"pass":        b.Pass,
+			"explanation": s.serializeExplanation(b.Explanation),
 		})
 	}
+	return bids
+}
 
-	players := []map[string]any{}
-	for _, p := range sess.Players {
-		hcp, _ := p.Hand.Evaluate()
-		players = append(players, map[string]any{
-			"position": p.Position.String(),
-			"hcp":      hcp,
-			"spades":   p.Hand.GetSuit(gamepkg.Spades),
-			"hearts":   p.Hand.GetSuit(gamepkg.Hearts),
-			"diamonds": p.Hand.GetSuit(gamepkg.Diamonds),
-			"clubs":    p.Hand.GetSuit(gamepkg.Clubs),
-		})
+// serializeExplanation gives what a call shows in the bidder's system, or
+// nil for a call the system has no rule for
+func (s *Server) serializeExplanation(e *gamepkg.Explanation) map[string]any {
+	if e == nil {
+		return nil
+	}
+	lengths := map[string]any{}
+	for suit, r := range e.Lengths {
+		lengths[s.strainString(suit)] = r
+	}
+	out := map[string]any{
+		"meaning":  e.Meaning,
+		"lengths":  lengths,
+		"balanced": e.Balanced,
+		"forcing":  e.Forcing,
+		"alert":    e.Alert,
+		"text":     e.String(),
+	}
+	if e.HCP != nil {
+		out["hcp"] = *e.HCP
 	}
+	if len(e.Points) > 0 {
+		out["points"] = e.Points
+	}
+	return out
+}
 
+// serializeInference gives what the auction has shown about each seat's
+// hand, keyed by position
+func (s *Server) serializeInference(a *gamepkg.Auction) map[string]any {
+	out := map[string]any{}
+	for pos := gamepkg.North; pos <= gamepkg.West; pos++ {
+		p := a.Picture(pos)
+		lengths := map[string]any{}
+		for suit := gamepkg.Clubs; suit <= gamepkg.Spades; suit++ {
+			lengths[s.strainString(suit)] = p.Lengths[suit]
+		}
+		out[pos.String()] = map[string]any{
+			"hcp":      p.HCP,
+			"lengths":  lengths,
+			"balanced": p.Balanced,
+			"text":     p.String(),
+		}
+	}
+	return out
+}
+
+// serializeHand describes a seat's hand suit by suit
+func (s *Server) serializeHand(pos gamepkg.Position, h *gamepkg.Hand) map[string]any {
+	hcp, _ := h.Evaluate()
+	return map[string]any{
+		"position": pos.String(),
+		"hcp":      hcp,
+		"spades":   h.GetSuit(gamepkg.Spades),
+		"hearts":   h.GetSuit(gamepkg.Hearts),
+		"diamonds": h.GetSuit(gamepkg.Diamonds),
+		"clubs":    h.GetSuit(gamepkg.Clubs),
+	}
+}
+
+// serializeContract describes the final contract, or returns nil while the auction is in progress
+func (s *Server) serializeContract(sess *Session) map[string]any {
+	c, err := gamepkg.NewContract(sess.Auction)
+	if err != nil {
+		return nil
+	}
+	if c.PassedOut {
+		return map[string]any{"passedOut": true}
+	}
+	// Until the play is over the score assumes the contract makes exactly
+	tricks := c.TricksRequired()
+	if sess.Play != nil && sess.Play.IsOver() {
+		tricks = sess.Play.DeclarerTricks()
+	}
+	score := gamepkg.ScoreContract(c, sess.Board.Vulnerable(c.Declarer), tricks)
 	return map[string]any{
-		"id":      sess.ID,
-		"dealer":  sess.Dealer.String(),
-		"players": players,
-		"auction": bids,
-		"complete": sess.Auction.IsAuctionComplete(),
+		"passedOut":     false,
+		"level":         c.Level,
+		"strain":        s.strainString(c.Strain),
+		"doubled":       c.Doubled,
+		"redoubled":     c.Redoubled,
+		"declarer":      c.Declarer.String(),
+		"dummy":         c.Dummy().String(),
+		"openingLeader": c.OpeningLeader().String(),
+		"tricks":        tricks,
+		"score":         score.TotalScore,
 	}
 }
 
+// serializePar compares the final contract, played double dummy, with par
+// for the deal, with the double-dummy tricks behind it, or returns nil
+// while the auction is in progress. Until the deal is solved it only
+// reports that par is pending, or why the solve stopped short. Scores are from North-South's point of view.
+func (s *Server) serializePar(sess *Session) map[string]any {
+	c, err := gamepkg.NewContract(sess.Auction)
+	if err != nil || sess.Tricks == nil {
+		return nil
+	}
+	table, ok := sess.Tricks.Ready()
+	if !ok {
+		if err := sess.Tricks.Err(); err != nil {
+			return map[string]any{"pending": false, "error": err.Error()}
+		}
+		return map[string]any{"pending": true}
+	}
+	vul := sess.Board.Vulnerability
+	par := gamepkg.CalculatePar(table, sess.Board.Dealer, vul)
+	score := gamepkg.DoubleDummyScore(c, table, vul)
+	tricks := map[string]map[string]int{}
+	for strain := gamepkg.Clubs; strain <= gamepkg.NoTrump; strain++ {
+		bySeat := map[string]int{}
+		for pos := gamepkg.North; pos <= gamepkg.West; pos++ {
+			bySeat[pos.String()] = table.Tricks(strain, pos)
+		}
+		tricks[s.strainString(strain)] = bySeat
+	}
+	out := map[string]any{
+		"pending":       false,
+		"contract":      par.Contract.String(),
+		"declarer":      par.Contract.Declarer.String(),
+		"tricks":        par.Tricks,
+		"score":         par.Score,
+		"contractScore": score,
+		"difference":    score - par.Score,
+		"imps":          gamepkg.IMPs(score - par.Score),
+		"table":         tricks,
+	}
+	if par.Contract.PassedOut {
+		delete(out, "declarer")
+		delete(out, "tricks")
+	}
+	return out
+}
+
+// serializePlay describes the card play, or returns nil before it starts
+func (s *Server) serializePlay(sess *Session) map[string]any {
+	p := sess.Play
+	if p == nil {
+		return nil
+	}
+	tricks := make([]map[string]any, 0, len(p.Tricks))
+	for _, t := range p.Tricks {
+		cards := make([]map[string]any, len(t.Cards))
+		for i, c := range t.Cards {
+			cards[i] = map[string]any{"position": t.PlayedBy(i).String(), "card": c.String()}
+		}
+		trick := map[string]any{"leader": t.Leader.String(), "cards": cards}
+		if t.Complete() {
+			trick["winner"] = t.Winner.String()
+		}
+		tricks = append(tricks, trick)
+	}
+	legal := []string{}
+	for _, c := range p.LegalCards() {
+		legal = append(legal, c.String())
+	}
+	out := map[string]any{
+		"turn":           p.Turn.String(),
+		"tricks":         tricks,
+		"declarerTricks": p.DeclarerTricks(),
+		"defenderTricks": p.TricksWon(p.Contract.Declarer.Side().Opponents()),
+		"legalCards":     legal,
+		"complete":       p.IsOver(),
+		"dummyVisible":   p.DummyVisible(),
+	}
+	if p.IsOver() {
+		delete(out, "turn")
+	}
+	return out
+}
+
 func (s *Server) strainString(strain gamepkg.Suit) string {
 	switch strain {
 	case gamepkg.Clubs:
@@ -281,6 +791,49 @@ func parsePosition(pos string) (gamepkg.Position, error) {
 	}
 }
 
+// parseSystems reads the bidding systems a request gives the partnerships,
+// and the defenses to 1NT they play with them, both keyed by "NS" and "EW"
+func parseSystems(names, defenses map[string]string) (gamepkg.Systems, error) {
+	var systems gamepkg.Systems
+	for key, name := range names {
+		side, err := parseSide(key)
+		if err != nil {
+			return systems, err
+		}
+		sys, ok := gamepkg.FindSystem(name)
+		if !ok {
+			return systems, fmt.Errorf("unknown bidding system: %s", name)
+		}
+		systems[side] = sys
+	}
+	for key, name := range defenses {
+		side, err := parseSide(key)
+		if err != nil {
+			return systems, err
+		}
+		defense, ok := gamepkg.FindNTDefense(name)
+		if !ok {
+			return systems, fmt.Errorf("unknown 1NT defense: %s", name)
+		}
+		if err := systems.PlayNTDefense(side, defense); err != nil {
+			return systems, err
+		}
+	}
+	return systems, nil
+}
+
+// parseSide reads a partnership, "NS" or "EW"
+func parseSide(key string) (gamepkg.Side, error) {
+	switch strings.ToUpper(strings.TrimSpace(key)) {
+	case "NS":
+		return gamepkg.NorthSouth, nil
+	case "EW":
+		return gamepkg.EastWest, nil
+	default:
+		return 0, fmt.Errorf("invalid partnership: %s", key)
+	}
+}
+
 // session store helpers
 func (s *Server) sessPut(sess *Session) {
 	s.mu.Lock()
@@ -294,7 +847,247 @@ func (s *Server) sessGet(id string) (*Session, bool) {
 	return sess, ok
 }
 
-// handleEvaluateBid evaluates a bid and provides feedback
+// handleSystems lists the bidding systems sessions may play
+// GET /api/systems
+func (s *Server) handleSystems(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	list := []map[string]any{}
+	for _, sys := range gamepkg.BuiltinSystems() {
+		list = append(list, map[string]any{"id": sys.ID, "name": sys.Name})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// handleNTDefenses lists the defenses to 1NT a partnership may play
+// GET /api/nt-defenses
+func (s *Server) handleNTDefenses(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	list := []map[string]any{{"id": gamepkg.NaturalNTDefense, "name": "Penalty double and natural overcalls"}}
+	for _, d := range gamepkg.NTDefenses() {
+		list = append(list, map[string]any{"id": d.ID, "name": d.Name})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// handleLeads creates opening lead problems
+// POST /api/leads -> bid a deal to a contract and set its opening lead as a problem
+// Optional JSON body: {"board": 5, "deal": "<29-digit deal ID>", "systems": {"NS": "sayc", "EW": "sayc"}, "ntDefenses": {"EW": "landy"}}.
+// Without a board number the next one in sequence is used; without a deal ID
+// hands are shuffled until the computer bids one to a contract. The
+// computer bids with the systems given, as for sessions.
+func (s *Server) handleLeads(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Board      int               `json:"board"`
+		Deal       string            `json:"deal"`
+		Systems    map[string]string `json:"systems"`
+		NTDefenses map[string]string `json:"ntDefenses"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+	if req.Board < 0 {
+		http.Error(w, "board number must be positive", http.StatusBadRequest)
+		return
+	}
+	systems, err := parseSystems(req.Systems, req.NTDefenses)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	board := s.pickBoard(req.Board)
+	var lp gamepkg.LeadProblem
+	if req.Deal != "" {
+		d, err := gamepkg.DealFromID(req.Deal)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if lp, err = gamepkg.NewLeadProblem(board, d, systems); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	} else {
+		lp = gamepkg.RandomLeadProblem(board, systems, rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+
+	id := uuid.New().String()
+	s.mu.Lock()
+	s.leads[id] = &lp
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, s.serializeLeadProblem(id, &lp))
+}
+
+// handleLeadByID manages single-problem endpoints
+// GET /api/leads/{id}
+// POST /api/leads/{id}/lead
+func (s *Server) handleLeadByID(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/leads/"), "/")
+	s.mu.RLock()
+	lp, ok := s.leads[parts[0]]
+	s.mu.RUnlock()
+	if !ok {
+		http.Error(w, "lead problem not found", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, http.StatusOK, s.serializeLeadProblem(parts[0], lp))
+	case len(parts) == 2 && parts[1] == "lead":
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		s.handlePostLead(w, r, lp)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// maxLeadLayouts caps the layouts a lead may be judged on, as each one is
+// solved double dummy after every lead.
+const maxLeadLayouts = 20
+
+// handlePostLead judges an opening lead against every other lead, double
+// dummy over layouts that fit the auction, and reveals the deal. The work
+// stops if the client goes away.
+// Expects JSON: {"card":"AS|10H|7C","layouts":10}; layouts is optional.
+func (s *Server) handlePostLead(w http.ResponseWriter, r *http.Request, lp *gamepkg.LeadProblem) {
+	var req struct {
+		Card    string `json:"card"`
+		Layouts int    `json:"layouts"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+	lead, err := gamepkg.ParseCard(req.Card)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !lp.Hand().Contains(lead) {
+		http.Error(w, fmt.Sprintf("%s does not hold the %s", lp.Leader(), lead), http.StatusBadRequest)
+		return
+	}
+	if req.Layouts == 0 {
+		req.Layouts = gamepkg.DefaultLeadLayouts
+	}
+	if req.Layouts < 1 || req.Layouts > maxLeadLayouts {
+		http.Error(w, fmt.Sprintf("layouts must be between 1 and %d", maxLeadLayouts), http.StatusBadRequest)
+		return
+	}
+
+	layouts, err := lp.SampleLayouts(r.Context(), req.Layouts, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if len(layouts) == 0 {
+		http.Error(w, "no layout fitting the auction was found", http.StatusUnprocessableEntity)
+		return
+	}
+	analysis, err := gamepkg.AnalyzeLeads(r.Context(), lp.Contract, layouts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	score, rank, _ := analysis.Score(lead)
+
+	leads := make([]map[string]any, 0, len(analysis.Scores))
+	for _, ls := range analysis.Scores {
+		leads = append(leads, map[string]any{
+			"card":     ls.Card.String(),
+			"tricks":   ls.Tricks,
+			"defeated": ls.Defeated,
+		})
+	}
+	players := make([]map[string]any, 0, 4)
+	for pos, h := range lp.Deal.Hands {
+		players = append(players, s.serializeHand(gamepkg.Position(pos), h))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"lead":         lead.String(),
+		"tricks":       score.Tricks,
+		"defeated":     score.Defeated,
+		"rank":         rank,
+		"best":         analysis.Scores[0].Card.String(),
+		"textbookLead": gamepkg.OpeningLead(lp.Hand(), lp.Contract, lp.Auction).String(),
+		"layouts":      analysis.Layouts,
+		"leads":        leads,
+		"dealId":       lp.Deal.ID(),
+		"players":      players,
+	})
+}
+
+// serializeLeadProblem describes a lead problem as the leader sees it: the
+// board, the auction, the contract and their own hand
+func (s *Server) serializeLeadProblem(id string, lp *gamepkg.LeadProblem) map[string]any {
+	c := lp.Contract
+	return map[string]any{
+		"id":      id,
+		"leader":  lp.Leader().String(),
+		"hand":    s.serializeHand(lp.Leader(), lp.Hand()),
+		"auction": s.serializeAuction(lp.Auction),
+		"contract": map[string]any{
+			"level":     c.Level,
+			"strain":    s.strainString(c.Strain),
+			"doubled":   c.Doubled,
+			"redoubled": c.Redoubled,
+			"declarer":  c.Declarer.String(),
+		},
+		"board": map[string]any{
+			"number":        lp.Board.Number,
+			"dealer":        lp.Board.Dealer.String(),
+			"vulnerability": lp.Board.Vulnerability.String(),
+		},
+	}
+}
+
+// handleEvaluateBid evaluates a bid and provides feedback. When it is the
+// position's turn to call, the bid is also compared with the recommended
+// call by simulation: layouts of the hidden hands that fit the auction are
+// bid out by the computer after each call and scored double dummy. The
+// simulation runs in the background; the response gives its ID, and
+// GET /api/evaluate-bid/{id} its result once done.
+// Expects JSON: {"sessionId":"<uuid>","position":"South","bid":"2H","layouts":12}; layouts is optional.
 func (s *Server) handleEvaluateBid(w http.ResponseWriter, r *http.Request) {
 	setCORSHeaders(w)
 	if r.Method == http.MethodOptions {
@@ -310,6 +1103,7 @@ func (s *Server) handleEvaluateBid(w http.ResponseWriter, r *http.Request) {
 		SessionID string `json:"sessionId"`
 		Position  string `json:"position"`
 		Bid       string `json:"bid"`
+		Layouts   int    `json:"layouts"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -354,6 +1148,13 @@ func (s *Server) handleEvaluateBid(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "player not found", http.StatusNotFound)
 		return
 	}
+	if req.Layouts == 0 {
+		req.Layouts = gamepkg.DefaultBidLayouts
+	}
+	if req.Layouts < 1 || req.Layouts > maxBidLayouts {
+		http.Error(w, fmt.Sprintf("layouts must be between 1 and %d", maxBidLayouts), http.StatusBadRequest)
+		return
+	}
 
 	// Get the AI's recommended bid
 	recommendedBid := player.MakeBid(sess.Auction)
@@ -372,20 +1173,154 @@ func (s *Server) handleEvaluateBid(w http.ResponseWriter, r *http.Request) {
 
 	// Prepare response
 	response := map[string]interface{}{
-		"isRecommended": isRecommended,
-		"recommendedBid":  recommendedBid.String(),
+		"isRecommended":  isRecommended,
+		"recommendedBid": recommendedBid.String(),
 	}
 
 	// Add explanation if bid is not recommended
+	var explanation string
 	if !isRecommended {
 		hcp, _ := player.Hand.Evaluate()
-		explanation := fmt.Sprintf("With %d HCP, the recommended bid is %s", hcp, recommendedBid.String())
+		explanation = fmt.Sprintf("With %d HCP, the recommended bid is %s", hcp, recommendedBid.String())
 		response["explanation"] = explanation
 	}
 
+	// Simulate the bid against the recommended call
+	if pos == sess.Dealer && !sess.Auction.IsOver() {
+		if err := sess.Auction.ValidateBid(bid); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctx, cancel := context.WithTimeout(s.ctx, bidEvaluationTimeout)
+		job := &bidEvaluation{cancel: cancel, done: make(chan struct{})}
+		// The session moves on while the simulation runs, so it works on
+		// copies of the hand and auction.
+		hand := gamepkg.NewHand(append([]gamepkg.Card(nil), player.Hand.Cards...))
+		auction := &gamepkg.Auction{Bids: append([]gamepkg.Bid(nil), sess.Auction.Bids...), Vulnerability: sess.Auction.Vulnerability}
+		started := s.work.tryGo(func() {
+			defer close(job.done)
+			defer cancel()
+			e, err := gamepkg.EvaluateCalls(ctx, sess.Board, sess.Systems, hand, auction, []gamepkg.Bid{bid}, req.Layouts,
+				rand.New(rand.NewSource(time.Now().UnixNano())))
+			switch {
+			case errors.Is(err, gamepkg.ErrNoLayouts):
+				job.result = map[string]any{}
+			case err != nil:
+				job.err = err
+			default:
+				job.result = map[string]any{"evaluation": s.serializeCallEvaluation(e)}
+				if !isRecommended {
+					mine := e.Results[len(e.Results)-1]
+					job.result["explanation"] = explanation + fmt.Sprintf(". Over %d simulated layouts %s scores %+.0f on average against %+.0f for %s (%+.1f IMPs)",
+						e.Layouts, bid, mine.Score, e.Results[0].Score, e.Recommended, mine.IMPs)
+				}
+			}
+		})
+		if !started {
+			cancel()
+			w.Header().Set("Retry-After", "5")
+			http.Error(w, "too many simulations running, try again shortly", http.StatusServiceUnavailable)
+			return
+		}
+		id := uuid.New().String()
+		s.mu.Lock()
+		s.bidEvals[id] = job
+		job.idle = time.AfterFunc(s.bidEvalIdle, func() { s.dropBidEvaluation(id) })
+		s.mu.Unlock()
+		response["evaluationId"] = id
+	}
+
 	writeJSON(w, http.StatusOK, response)
 }
 
+// bidEvaluationTimeout bounds how long a bid may be simulated.
+const bidEvaluationTimeout = 2 * time.Minute
+
+// bidEvaluationIdle is how long a bid simulation is kept once its client
+// stops polling; a running one is stopped.
+const bidEvaluationIdle = 30 * time.Second
+
+// dropBidEvaluation stops a bid simulation and forgets it.
+func (s *Server) dropBidEvaluation(id string) {
+	s.mu.Lock()
+	job, ok := s.bidEvals[id]
+	delete(s.bidEvals, id)
+	s.mu.Unlock()
+	if ok {
+		job.idle.Stop()
+		job.cancel()
+	}
+}
+
+// handleBidEvaluationByID reports on a bid simulation
+// GET /api/evaluate-bid/{id} -> {"status": "pending"}, or once done {"status": "done", "evaluation": {...}, "explanation": "..."}
+// and the simulation is forgotten
+// DELETE /api/evaluate-bid/{id} -> stop the simulation and forget it
+func (s *Server) handleBidEvaluationByID(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/api/evaluate-bid/")
+	s.mu.RLock()
+	job, ok := s.bidEvals[id]
+	s.mu.RUnlock()
+	if !ok {
+		http.Error(w, "bid evaluation not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		select {
+		case <-job.done:
+		default:
+			job.idle.Reset(s.bidEvalIdle)
+			writeJSON(w, http.StatusOK, map[string]any{"status": "pending"})
+			return
+		}
+		s.dropBidEvaluation(id)
+		if job.err != nil {
+			http.Error(w, job.err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		response := map[string]any{"status": "done"}
+		for k, v := range job.result {
+			response[k] = v
+		}
+		writeJSON(w, http.StatusOK, response)
+	case http.MethodDelete:
+		s.dropBidEvaluation(id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// maxBidLayouts caps the layouts a bid may be simulated on, as every call
+// is bid out and solved double dummy on each one.
+const maxBidLayouts = 24
+
+// serializeCallEvaluation lists each call's average score for the bidder's
+// side and average IMPs against the recommended call, recommended call first
+func (s *Server) serializeCallEvaluation(e gamepkg.CallEvaluation) map[string]any {
+	calls := make([]map[string]any, 0, len(e.Results))
+	for _, res := range e.Results {
+		calls = append(calls, map[string]any{
+			"call":     res.Call.String(),
+			"score":    res.Score,
+			"imps":     res.IMPs,
+			"contract": res.Contract.String(),
+		})
+	}
+	return map[string]any{
+		"layouts":     e.Layouts,
+		"recommended": e.Recommended.String(),
+		"calls":       calls,
+	}
+}
+
 // writeJSON is a helper to encode responses
 func writeJSON(w http.ResponseWriter, status int, payload any) {
 	setCORSHeaders(w)
@@ -398,6 +1333,6 @@ func writeJSON(w http.ResponseWriter, status int, payload any) {
 func setCORSHeaders(w http.ResponseWriter) {
 	h := w.Header()
 	h.Set("Access-Control-Allow-Origin", "*")
-	h.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	h.Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
 	h.Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 }
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// do sends a request to the server's routes and decodes a JSON response
+// into out, if given.
+func do(t *testing.T, mux *http.ServeMux, method, path string, body any, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(method, path, &buf))
+	if out != nil && rec.Code/100 == 2 {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: %v in %s", method, path, err, rec.Body)
+		}
+	}
+	return rec
+}
+
+func TestBidEvaluationLifecycle(t *testing.T) {
+	s := New()
+	defer s.Close()
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+
+	var sess struct {
+		ID     string `json:"id"`
+		Dealer string `json:"dealer"`
+	}
+	if rec := do(t, mux, http.MethodPost, "/api/sessions", map[string]any{"board": 1}, &sess); rec.Code != http.StatusCreated {
+		t.Fatalf("creating a session: %d %s", rec.Code, rec.Body)
+	}
+	evaluate := func() string {
+		t.Helper()
+		var resp struct {
+			EvaluationID string `json:"evaluationId"`
+		}
+		rec := do(t, mux, http.MethodPost, "/api/evaluate-bid",
+			map[string]any{"sessionId": sess.ID, "position": sess.Dealer, "bid": "Pass", "layouts": 1}, &resp)
+		if rec.Code != http.StatusOK || resp.EvaluationID == "" {
+			t.Fatalf("evaluating a bid: %d %s", rec.Code, rec.Body)
+		}
+		return resp.EvaluationID
+	}
+	path := func(id string) string { return "/api/evaluate-bid/" + id }
+
+	t.Run("fetched result is forgotten", func(t *testing.T) {
+		id := evaluate()
+		deadline := time.Now().Add(time.Minute)
+		for {
+			var sim struct {
+				Status string `json:"status"`
+			}
+			rec := do(t, mux, http.MethodGet, path(id), nil, &sim)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GET: %d %s", rec.Code, rec.Body)
+			}
+			if sim.Status == "done" {
+				break
+			}
+			if sim.Status != "pending" {
+				t.Fatalf("status %q, want pending or done", sim.Status)
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("simulation still pending after a minute")
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		if rec := do(t, mux, http.MethodGet, path(id), nil, nil); rec.Code != http.StatusNotFound {
+			t.Errorf("GET after the result was fetched: %d, want 404", rec.Code)
+		}
+	})
+
+	t.Run("DELETE stops and forgets", func(t *testing.T) {
+		id := evaluate()
+		if rec := do(t, mux, http.MethodDelete, path(id), nil, nil); rec.Code != http.StatusNoContent {
+			t.Fatalf("DELETE: %d, want 204", rec.Code)
+		}
+		if rec := do(t, mux, http.MethodGet, path(id), nil, nil); rec.Code != http.StatusNotFound {
+			t.Errorf("GET after DELETE: %d, want 404", rec.Code)
+		}
+		if rec := do(t, mux, http.MethodDelete, path(id), nil, nil); rec.Code != http.StatusNotFound {
+			t.Errorf("second DELETE: %d, want 404", rec.Code)
+		}
+	})
+
+	t.Run("unpolled simulation is dropped", func(t *testing.T) {
+		s.bidEvalIdle = 10 * time.Millisecond
+		defer func() { s.bidEvalIdle = bidEvaluationIdle }()
+		id := evaluate()
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			s.mu.RLock()
+			_, ok := s.bidEvals[id]
+			s.mu.RUnlock()
+			if !ok {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("simulation kept after going unpolled")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if rec := do(t, mux, http.MethodGet, path(id), nil, nil); rec.Code != http.StatusNotFound {
+			t.Errorf("GET after going unpolled: %d, want 404", rec.Code)
+		}
+	})
+
+	t.Run("busy server refuses", func(t *testing.T) {
+		work := s.work
+		s.work = newWorkPool(0)
+		defer func() { s.work = work }()
+		s.mu.RLock()
+		before := len(s.bidEvals)
+		s.mu.RUnlock()
+		rec := do(t, mux, http.MethodPost, "/api/evaluate-bid",
+			map[string]any{"sessionId": sess.ID, "position": sess.Dealer, "bid": "Pass", "layouts": 1}, nil)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("POST with the pool full: %d, want 503", rec.Code)
+		}
+		if rec.Header().Get("Retry-After") == "" {
+			t.Error("503 without Retry-After")
+		}
+		s.mu.RLock()
+		after := len(s.bidEvals)
+		s.mu.RUnlock()
+		if after != before {
+			t.Errorf("refused simulation was stored: %d simulations, had %d", after, before)
+		}
+	})
+}
+
+func TestParWaitsForThePool(t *testing.T) {
+	s := New()
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+	// With no room in the pool the solve can only stop when the server does.
+	s.work = newWorkPool(0)
+
+	var sess struct {
+		ID     string         `json:"id"`
+		Dealer string         `json:"dealer"`
+		Par    map[string]any `json:"par"`
+	}
+	if rec := do(t, mux, http.MethodPost, "/api/sessions", map[string]any{"board": 1}, &sess); rec.Code != http.StatusCreated {
+		t.Fatalf("creating a session: %d %s", rec.Code, rec.Body)
+	}
+	for _, bid := range []string{"1C", "Pass", "Pass", "Pass"} {
+		if rec := do(t, mux, http.MethodPost, "/api/sessions/"+sess.ID+"/bid", map[string]any{"position": sess.Dealer, "bid": bid}, &sess); rec.Code != http.StatusOK {
+			t.Fatalf("bidding %s: %d %s", bid, rec.Code, rec.Body)
+		}
+	}
+	if sess.Par["pending"] != true {
+		t.Fatalf("par = %v, want pending", sess.Par)
+	}
+
+	s.Close()
+	deadline := time.Now().Add(10 * time.Second)
+	for sess.Par["pending"] == true {
+		if time.Now().After(deadline) {
+			t.Fatal("par still pending after the server was closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+		do(t, mux, http.MethodGet, "/api/sessions/"+sess.ID, nil, &sess)
+	}
+	if sess.Par["error"] == nil {
+		t.Errorf("par = %v, want the error that stopped the solve", sess.Par)
+	}
+}
@@ -1,7 +1,21 @@
 const API = {
-  createSession: async () => {
-    const res = await fetch('/api/sessions', { method: 'POST' });
-    if (!res.ok) throw new Error('Failed to create session');
+  listSystems: async () => {
+    const res = await fetch('/api/systems');
+    if (!res.ok) throw new Error('Failed to list bidding systems');
+    return res.json();
+  },
+  listNTDefenses: async () => {
+    const res = await fetch('/api/nt-defenses');
+    if (!res.ok) throw new Error('Failed to list 1NT defenses');
+    return res.json();
+  },
+  createSession: async (systems, ntDefenses) => {
+    const res = await fetch('/api/sessions', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ systems, ntDefenses })
+    });
+    if (!res.ok) throw new Error(await res.text());
     return res.json();
   },
   getSession: async (id) => {
@@ -9,6 +23,11 @@ const API = {
     if (!res.ok) throw new Error('Failed to fetch session');
     return res.json();
   },
+  getInference: async (id) => {
+    const res = await fetch(`/api/sessions/${id}/inference`);
+    if (!res.ok) throw new Error('Failed to fetch what the auction has shown');
+    return res.json();
+  },
   postBid: async (id, position, bid) => {
     const res = await fetch(`/api/sessions/${id}/bid`, {
       method: 'POST',
@@ -18,6 +37,15 @@ const API = {
     if (!res.ok) throw new Error(await res.text());
     return res.json();
   },
+  postPlay: async (id, position, card) => {
+    const res = await fetch(`/api/sessions/${id}/play`, {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ position, card })
+    });
+    if (!res.ok) throw new Error(await res.text());
+    return res.json();
+  },
   evaluateBid: async (sessionId, position, bid) => {
     const res = await fetch('/api/evaluate-bid', {
       method: 'POST',
@@ -26,16 +54,57 @@ const API = {
     });
     if (!res.ok) throw new Error(await res.text());
     return res.json();
+  },
+  getBidSimulation: async (id) => {
+    const res = await fetch(`/api/evaluate-bid/${id}`);
+    if (!res.ok) throw new Error(await res.text());
+    return res.json();
+  },
+  // Stop a bid simulation; keepalive lets the request outlive the page
+  cancelBidSimulation: (id) => fetch(`/api/evaluate-bid/${id}`, { method: 'DELETE', keepalive: true }).catch(() => {}),
+  // Poll a bid simulation until it is done. The server forgets it once the
+  // result is fetched; if we stop waiting first, we tell it to stop.
+  waitForBidSimulation: async (id) => {
+    pendingSimulation = id;
+    try {
+      for (;;) {
+        const sim = await API.getBidSimulation(id);
+        if (sim.status !== 'pending') return sim;
+        await new Promise((resolve) => setTimeout(resolve, 500));
+      }
+    } catch (e) {
+      API.cancelBidSimulation(id);
+      throw e;
+    } finally {
+      pendingSimulation = null;
+    }
   }
 };
 
+// The bid simulation being waited for, stopped if the page is left
+let pendingSimulation = null;
+window.addEventListener('pagehide', () => {
+  if (pendingSimulation) API.cancelBidSimulation(pendingSimulation);
+});
+
 const el = (id) => document.getElementById(id);
 
 function render(state) {
   if (!state) return;
   el('sessionId').textContent = state.id;
   el('dealer').textContent = state.dealer;
+  if (state.board) {
+    el('board').textContent = `${state.board.number} (Dealer ${state.board.dealer}, Vul ${state.board.vulnerability})`;
+  }
   el('complete').textContent = String(state.complete);
+  el('contract').textContent = formatContract(state.contract);
+  if (state.systems) {
+    el('systems').textContent = `N-S ${state.systems.NS}, E-W ${state.systems.EW}`;
+  }
+  el('pbnLink').href = `/api/sessions/${state.id}/pbn`;
+  el('pbnLink').style.display = '';
+  el('linLink').href = `/api/sessions/${state.id}/lin`;
+  el('linLink').style.display = '';
   // Show current turn (same as dealer)
   const current = state.dealer;
   const turnEl = el('currentTurn');
@@ -75,13 +144,55 @@ function render(state) {
 
   const tbody = el('auction').querySelector('tbody');
   tbody.innerHTML = state.auction.length > 0 
-    ? state.auction.map(a => `<tr><td>${a.position}</td><td>${
+    ? state.auction.map(a => `<tr${a.explanation && a.explanation.alert ? ' class="alert"' : ''}><td>${a.position}</td><td>${
         a.pass ? 'Pass' : (a.redouble ? 'XX' : (a.double ? 'X' : `${a.level}${a.strain}`))
-      }</td></tr>`).join('')
-    : '<tr><td colspan="2">No bids yet</td></tr>';
+      }</td><td>${formatExplanation(a.explanation)}</td></tr>`).join('')
+    : '<tr><td colspan="3">No bids yet</td></tr>';
 
   // Update bid button enabled/disabled state
   updateBidAvailability(state);
+  renderPlay(state.play);
+  API.getInference(state.id).then(renderInference).catch(() => {});
+}
+
+// renderInference lists what the auction has shown about each seat's hand.
+function renderInference(inference) {
+  el('inference').innerHTML = ['North', 'East', 'South', 'West']
+    .map(pos => `<div><b>${pos}</b>: ${inference[pos].text || 'nothing yet'}</div>`)
+    .join('');
+}
+
+// formatExplanation describes what a call shows: an alert, its meaning,
+// and the HCP, suit lengths and forcing status behind it.
+function formatExplanation(e) {
+  if (!e) return '-';
+  const range = (r, max) => r[0] === r[1] ? `${r[0]}` : (r[1] >= max ? `${r[0]}+` : `${r[0]}-${r[1]}`);
+  const symbols = { S: '♠', H: '♥', D: '♦', C: '♣' };
+  const details = [];
+  if (e.hcp) details.push(`${range(e.hcp, 37)} HCP`);
+  for (const [metric, r] of Object.entries(e.points || {})) details.push(`${r[0]}-${r[1]} ${metric}`);
+  for (const suit of ['S', 'H', 'D', 'C']) {
+    if (e.lengths && e.lengths[suit]) details.push(`${range(e.lengths[suit], 13)}${symbols[suit]}`);
+  }
+  if (e.balanced) details.push('balanced');
+  if (e.forcing) details.push('forcing');
+  const alert = e.alert ? '<b>Alert!</b> ' : '';
+  const meaning = e.meaning || '';
+  return `${alert}${meaning}${details.length ? ` <small>(${details.join(', ')})</small>` : ''}` || '-';
+}
+
+function renderPlay(play) {
+  el('playSection').style.display = play ? '' : 'none';
+  if (!play) return;
+  el('playTurn').textContent = play.complete ? 'Play complete' : play.turn;
+  el('playTricks').textContent = `Declarer ${play.declarerTricks}, defenders ${play.defenderTricks}`;
+  el('legalCards').textContent = play.legalCards.join(' ') || '-';
+  const tbody = el('tricks').querySelector('tbody');
+  tbody.innerHTML = play.tricks.length > 0
+    ? play.tricks.map((t, i) => `<tr><td>${i + 1}</td><td>${
+        t.cards.map(c => `${c.position.charAt(0)}: ${c.card}`).join(', ')
+      }</td><td>${t.winner || '-'}</td></tr>`).join('')
+    : '<tr><td colspan="3">No cards played yet</td></tr>';
 }
 
 async function main() {
@@ -99,9 +210,25 @@ async function main() {
     }
   }
 
+  try {
+    const systems = await API.listSystems();
+    for (const id of ['nsSystem', 'ewSystem']) {
+      el(id).innerHTML = systems.map(s => `<option value="${s.id}">${s.name}</option>`).join('');
+    }
+    const defenses = await API.listNTDefenses();
+    for (const id of ['nsDefense', 'ewDefense']) {
+      el(id).innerHTML = defenses.map(d => `<option value="${d.id}">${d.name}</option>`).join('');
+    }
+  } catch (e) {
+    el('message').textContent = e.message;
+  }
+
   el('newSessionBtn').addEventListener('click', async () => {
     try {
-      const state = await API.createSession();
+      const state = await API.createSession(
+        { NS: el('nsSystem').value, EW: el('ewSystem').value },
+        { NS: el('nsDefense').value, EW: el('ewDefense').value }
+      );
       sessionId = state.id;
       lastState = state;
       render(state);
@@ -131,8 +258,14 @@ async function main() {
     // Normalize: uppercase and map single trailing N to NT
     bid = normalizeBid(bid);
     try {
-      // First evaluate the bid
+      // First evaluate the bid; the simulation takes a few seconds
+      el('message').textContent = 'Evaluating your bid over simulated layouts...';
+      el('message').className = 'status';
       const evaluation = await API.evaluateBid(sessionId, position, bid);
+      if (evaluation.evaluationId) {
+        const sim = await API.waitForBidSimulation(evaluation.evaluationId);
+        if (sim.explanation) evaluation.explanation = sim.explanation;
+      }
       
       // Show evaluation feedback
       const messageEl = el('message');
@@ -162,6 +295,20 @@ async function main() {
     }
   });
 
+  el('playCardBtn').addEventListener('click', async () => {
+    if (!sessionId || !lastState || !lastState.play) return;
+    try {
+      const state = await API.postPlay(sessionId, lastState.play.turn, el('card').value.trim());
+      lastState = state;
+      render(state);
+      el('card').value = '';
+      el('card').focus();
+      el('message').textContent = '';
+    } catch (e) {
+      el('message').textContent = e.message;
+    }
+  });
+
   // Recompute availability and update input validation when user types
   el('position').addEventListener('change', () => updateBidAvailability(lastState));
   
@@ -213,6 +360,14 @@ function updateBidAvailability(state) {
   }
 }
 
+// Render the final contract, e.g. "4HX by South (dummy North, lead West)"
+function formatContract(c) {
+  if (!c) return '-';
+  if (c.passedOut) return 'Passed out';
+  const dbl = c.redoubled ? 'XX' : (c.doubled ? 'X' : '');
+  return `${c.level}${c.strain}${dbl} by ${c.declarer} (dummy ${c.dummy}, lead ${c.openingLeader})`;
+}
+
 // Client-side validation: accepts contract bids (1-7 + C/D/H/S/NT/N) and special tokens Pass/P, Double/DBL/X, Redouble/RDBL/XX
 function isValidBidFormat(input) {
   const s = (input || '').trim().toLowerCase();